@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	imperatorv1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
+	imperatorv1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
 	"github.com/tenzen-y/imperator/pkg/consts"
 	"github.com/tenzen-y/imperator/pkg/controllers"
 	"github.com/tenzen-y/imperator/pkg/version"
@@ -47,19 +48,23 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 
 	// flags
-	metricsAddr          string
-	probeAddr            string
-	enableLeaderElection bool
-	leaderElectionID     string
-	syncPeriod           time.Duration
-	webhookPort          int
-	webhookCertDir       string
+	metricsAddr            string
+	probeAddr              string
+	enableLeaderElection   bool
+	leaderElectionID       string
+	syncPeriod             time.Duration
+	webhookPort            int
+	webhookCertDir         string
+	enableWorkloadInjector bool
+	namespacePolicy        string
+	checkMachineAccess     bool
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(imperatorv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(imperatorv1beta1.AddToScheme(scheme))
 
 	// +kubebuilder:scaffold:scheme
 }
@@ -75,6 +80,12 @@ func initFlags() {
 	pflag.IntVar(&webhookPort, "webhook-port", 9443, "The port that the webhook server serves at.")
 	pflag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
 		"The directory that contains the server key and certificate.")
+	pflag.BoolVar(&enableWorkloadInjector, "enable-workload-injector", false,
+		"Enable the webhook injecting resources into Pod templates of Deployments, StatefulSets, Jobs and CronJobs.")
+	pflag.StringVar(&namespacePolicy, "namespace-policy", string(imperatorv1alpha1.NamespacePolicyIgnore),
+		"How to handle Guest Pods in namespaces without the label, imperator.tenzen.io/inject-resource=enabled. Deny or Ignore.")
+	pflag.BoolVar(&checkMachineAccess, "check-machine-access", false,
+		"Require the verb, use for machines named after machine-group to the user or the service account creating Guest Pods.")
 }
 
 func main() {
@@ -131,14 +142,42 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 }
 
 func setupWebhooks(ctx context.Context, mgr ctrl.Manager) {
+	policy := imperatorv1alpha1.NamespacePolicy(namespacePolicy)
+	if policy != imperatorv1alpha1.NamespacePolicyDeny && policy != imperatorv1alpha1.NamespacePolicyIgnore {
+		setupLog.Error(fmt.Errorf("invalid namespace policy <%s>", namespacePolicy), "unable to create webhook", "webhook", "Pod")
+		os.Exit(1)
+	}
 	if err := (&imperatorv1alpha1.Machine{}).SetupWebhookWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Machine")
 		os.Exit(1)
 	}
+	// conversion webhooks
+	if err := (&imperatorv1beta1.Machine{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", "webhook", "Machine")
+		os.Exit(1)
+	}
+	if err := (&imperatorv1beta1.MachineNodePool{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", "webhook", "MachineNodePool")
+		os.Exit(1)
+	}
+	if err := (&imperatorv1beta1.MachineQuota{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", "webhook", "MachineQuota")
+		os.Exit(1)
+	}
 	// pod resource injector
 	mgr.GetWebhookServer().Register(consts.PodResourceInjectorPath, &webhook.Admission{
-		Handler: imperatorv1alpha1.NewResourceInjector(mgr.GetClient()),
+		Handler: imperatorv1alpha1.NewResourceInjector(mgr.GetClient(), policy, checkMachineAccess),
 	})
+	// reservation validator
+	mgr.GetWebhookServer().Register(consts.ReservationValidatorPath, &webhook.Admission{
+		Handler: imperatorv1alpha1.NewReservationValidator(mgr.GetClient()),
+	})
+	// workload resource injector
+	if enableWorkloadInjector {
+		mgr.GetWebhookServer().Register(consts.WorkloadResourceInjectorPath, &webhook.Admission{
+			Handler: imperatorv1alpha1.NewWorkloadResourceInjector(mgr.GetClient(), policy, checkMachineAccess),
+		})
+	}
 }
 
 func setupHealthzCheck(mgr ctrl.Manager) {
@@ -0,0 +1,91 @@
+#!/usr/bin/env bash
+
+# Copyright 2021 Yuki Iwai (@tenzen-y)
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Generate clientset, informers, listers and apply configurations for the imperator API into pkg/client.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+PROJECT_ROOT=$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)
+CODE_GENERATOR_BIN=${CODE_GENERATOR_BIN:-${PROJECT_ROOT}/bin}
+MODULE=github.com/tenzen-y/imperator
+OUTPUT_PKG=${MODULE}/pkg/client
+GROUP=imperator
+GROUP_VERSIONS="v1alpha1 v1beta1"
+BOILERPLATE=${PROJECT_ROOT}/hack/boilerplate/boilerplate.go.txt
+
+# code-generator treats packages whose path ends with "api/<version>" as the core group,
+# so the API packages are copied to pkg/apis/<group>/<version> while generating.
+TMP_APIS_DIR=${PROJECT_ROOT}/pkg/apis
+TMP_APIS_PKG=${MODULE}/pkg/apis/${GROUP}
+OUTPUT_BASE=$(mktemp -d)
+trap 'rm -rf "${OUTPUT_BASE}" "${TMP_APIS_DIR}"' EXIT
+
+INPUT_DIRS=""
+mkdir -p "${TMP_APIS_DIR}/${GROUP}"
+for gv in ${GROUP_VERSIONS}; do
+  cp -r "${PROJECT_ROOT}/pkg/api/${gv}" "${TMP_APIS_DIR}/${GROUP}/${gv}"
+  INPUT_DIRS+="${TMP_APIS_PKG}/${gv},"
+done
+INPUT_DIRS=${INPUT_DIRS%,}
+
+cd "${PROJECT_ROOT}"
+
+echo "Generating apply configurations"
+"${CODE_GENERATOR_BIN}/applyconfiguration-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  --output-package "${OUTPUT_PKG}/applyconfiguration" \
+  --output-base "${OUTPUT_BASE}" \
+  --go-header-file "${BOILERPLATE}"
+
+echo "Generating clientset"
+"${CODE_GENERATOR_BIN}/client-gen" \
+  --clientset-name versioned \
+  --input-base "${MODULE}/pkg/apis" \
+  --input "${GROUP}/${GROUP_VERSIONS// /,${GROUP}/}" \
+  --output-package "${OUTPUT_PKG}/clientset" \
+  --apply-configuration-package "${OUTPUT_PKG}/applyconfiguration" \
+  --output-base "${OUTPUT_BASE}" \
+  --go-header-file "${BOILERPLATE}"
+
+echo "Generating listers"
+"${CODE_GENERATOR_BIN}/lister-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  --output-package "${OUTPUT_PKG}/listers" \
+  --output-base "${OUTPUT_BASE}" \
+  --go-header-file "${BOILERPLATE}"
+
+echo "Generating informers"
+"${CODE_GENERATOR_BIN}/informer-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  --versioned-clientset-package "${OUTPUT_PKG}/clientset/versioned" \
+  --listers-package "${OUTPUT_PKG}/listers" \
+  --output-package "${OUTPUT_PKG}/informers" \
+  --output-base "${OUTPUT_BASE}" \
+  --go-header-file "${BOILERPLATE}"
+
+find "${OUTPUT_BASE}/${OUTPUT_PKG}" -name '*.go' -exec \
+  sed -i.bak "s|${TMP_APIS_PKG}/|${MODULE}/pkg/api/|g" {} \; -exec rm -f {}.bak \;
+
+# applyconfiguration-gen v0.22 cannot map meta/v1.OwnerReference to its external apply configuration
+# because --external-applyconfigurations splits the package path on ".", so WithOwnerReferences is
+# rewritten to the signature client-go uses.
+find "${OUTPUT_BASE}/${OUTPUT_PKG}/applyconfiguration" -name '*.go' -exec \
+  perl -0pi -e 's/WithOwnerReferences\(values \.\.\.metav1\.OwnerReference\)(.*?)\n\tfor i := range values \{\n\t\tb\.OwnerReferences = append\(b\.OwnerReferences, values\[i\]\)/WithOwnerReferences(values ...*v1.OwnerReferenceApplyConfiguration)$1\n\tfor i := range values {\n\t\tif values[i] == nil {\n\t\t\tpanic("nil value passed to WithOwnerReferences")\n\t\t}\n\t\tb.OwnerReferences = append(b.OwnerReferences, *values[i])/s' {} \;
+
+rm -rf "${PROJECT_ROOT}/pkg/client"
+cp -r "${OUTPUT_BASE}/${OUTPUT_PKG}" "${PROJECT_ROOT}/pkg/client"
@@ -17,9 +17,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/tenzen-y/imperator/pkg/consts"
 )
@@ -57,19 +60,59 @@ func GenerateAffinityMatchExpression(machineType *MachineType, machineGroup stri
 		},
 	}
 	if machineType.Spec.GPU != nil {
-		// gpuSelector: []string{"nvidia.com/gpu.family", "ampere"}
-		if gpuSelector := getGPUSelector(*machineType.Spec.GPU); len(gpuSelector) == 2 {
-			affinityMatchExpressions = append(affinityMatchExpressions, corev1.NodeSelectorRequirement{
-				Key:      gpuSelector[0],
-				Operator: corev1.NodeSelectorOpIn,
-				Values:   []string{gpuSelector[1]},
-			})
-		}
+		affinityMatchExpressions = append(affinityMatchExpressions, generateGPUMatchExpression(*machineType.Spec.GPU)...)
 	}
 
 	return affinityMatchExpressions
 }
 
+// generateGPUMatchExpression generates matchExpressions to select Nodes with the GPU from NVIDIA GPU labels and gpu.selector.
+func generateGPUMatchExpression(gpuSpec GPUSpec) []corev1.NodeSelectorRequirement {
+	var matchExpressions []corev1.NodeSelectorRequirement
+	// gpuSelector: []string{"nvidia.com/gpu.family", "ampere"}
+	if gpuSelector := getGPUSelector(gpuSpec); len(gpuSelector) == 2 {
+		matchExpressions = append(matchExpressions, corev1.NodeSelectorRequirement{
+			Key:      gpuSelector[0],
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{gpuSelector[1]},
+		})
+	}
+	for _, req := range gpuSpec.Selector {
+		matchExpressions = append(matchExpressions, *req.DeepCopy())
+	}
+	return matchExpressions
+}
+
+// NodeSelectorRequirementsAsSelector converts matchExpressions of NodeSelectorTerm to labels.Selector.
+func NodeSelectorRequirementsAsSelector(matchExpressions []corev1.NodeSelectorRequirement) (labels.Selector, error) {
+	selector := labels.NewSelector()
+	for _, expr := range matchExpressions {
+		var op selection.Operator
+		switch expr.Operator {
+		case corev1.NodeSelectorOpIn:
+			op = selection.In
+		case corev1.NodeSelectorOpNotIn:
+			op = selection.NotIn
+		case corev1.NodeSelectorOpExists:
+			op = selection.Exists
+		case corev1.NodeSelectorOpDoesNotExist:
+			op = selection.DoesNotExist
+		case corev1.NodeSelectorOpGt:
+			op = selection.GreaterThan
+		case corev1.NodeSelectorOpLt:
+			op = selection.LessThan
+		default:
+			return nil, fmt.Errorf("<%s> is not a valid node selector operator", expr.Operator)
+		}
+		r, err := labels.NewRequirement(expr.Key, op, expr.Values)
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*r)
+	}
+	return selector, nil
+}
+
 func GenerateToleration(machineTypeName, machineGroup string) []corev1.Toleration {
 	machineTypeTolerationKey := GenerateMachineTypeLabelTaintKey(machineTypeName)
 	return []corev1.Toleration{
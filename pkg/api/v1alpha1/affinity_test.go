@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/tenzen-y/imperator/pkg/consts"
 )
@@ -61,3 +63,241 @@ func TestGetGPUSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateGPUMatchExpression(t *testing.T) {
+	tests := []struct {
+		description string
+		gpuSpec     GPUSpec
+		expected    []corev1.NodeSelectorRequirement
+	}{
+		{
+			description: "Use NVIDIA GPU label",
+			gpuSpec:     GPUSpec{Type: "nvidia.com/gpu", Family: "ampere"},
+			expected: []corev1.NodeSelectorRequirement{{
+				Key:      consts.NvidiaGPUFamilyKey,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{"ampere"},
+			}},
+		},
+		{
+			description: "Use selector",
+			gpuSpec: GPUSpec{
+				Type: "amd.com/gpu",
+				Selector: []corev1.NodeSelectorRequirement{{
+					Key:      "amd.com/gpu.family",
+					Operator: corev1.NodeSelectorOpExists,
+				}},
+			},
+			expected: []corev1.NodeSelectorRequirement{{
+				Key:      "amd.com/gpu.family",
+				Operator: corev1.NodeSelectorOpExists,
+			}},
+		},
+		{
+			description: "Use NVIDIA GPU label with selector",
+			gpuSpec: GPUSpec{
+				Type:    "nvidia.com/gpu",
+				Product: "NVIDIA-GeForce-RTX-3080",
+				Selector: []corev1.NodeSelectorRequirement{{
+					Key:      "nvidia.com/gpu.memory",
+					Operator: corev1.NodeSelectorOpGt,
+					Values:   []string{"10000"},
+				}},
+			},
+			expected: []corev1.NodeSelectorRequirement{
+				{
+					Key:      consts.NvidiaGPUProductKey,
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"NVIDIA-GeForce-RTX-3080"},
+				},
+				{
+					Key:      "nvidia.com/gpu.memory",
+					Operator: corev1.NodeSelectorOpGt,
+					Values:   []string{"10000"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			actual := generateGPUMatchExpression(test.gpuSpec)
+			if diff := cmp.Diff(actual, test.expected); diff != "" {
+				t.Fatalf("\ndiff: %v\n; actual and expected are different", diff)
+			}
+		})
+	}
+}
+
+func TestNodeSelectorRequirementsAsSelector(t *testing.T) {
+	nodeLabels := labels.Set{
+		"gpu.intel.com/device-id.0300-56a0.present": "true",
+		"amd.com/gpu.vram":                          "16G",
+		"amd.com/gpu.count":                         "2",
+	}
+	tests := []struct {
+		description      string
+		matchExpressions []corev1.NodeSelectorRequirement
+		matches          bool
+		err              bool
+	}{
+		{
+			description: "In and Exists match",
+			matchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: "amd.com/gpu.vram", Operator: corev1.NodeSelectorOpIn, Values: []string{"16G", "32G"}},
+				{Key: "gpu.intel.com/device-id.0300-56a0.present", Operator: corev1.NodeSelectorOpExists},
+			},
+			matches: true,
+		},
+		{
+			description: "Gt does not match",
+			matchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: "amd.com/gpu.count", Operator: corev1.NodeSelectorOpGt, Values: []string{"2"}},
+			},
+			matches: false,
+		},
+		{
+			description: "DoesNotExist matches",
+			matchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: consts.NvidiaGPUFamilyKey, Operator: corev1.NodeSelectorOpDoesNotExist},
+			},
+			matches: true,
+		},
+		{
+			description: "Invalid operator",
+			matchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: "amd.com/gpu.vram", Operator: "Equals", Values: []string{"16G"}},
+			},
+			err: true,
+		},
+		{
+			description: "In requires values",
+			matchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: "amd.com/gpu.vram", Operator: corev1.NodeSelectorOpIn},
+			},
+			err: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			selector, err := NodeSelectorRequirementsAsSelector(test.matchExpressions)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error; %v", err)
+			}
+			if actual := selector.Matches(nodeLabels); actual != test.matches {
+				t.Fatalf("GOT: %v, WANT: %v", actual, test.matches)
+			}
+		})
+	}
+}
+
+func TestInjectPodAffinity(t *testing.T) {
+	injected := []corev1.NodeSelectorRequirement{
+		{
+			Key:      GenerateMachineTypeLabelTaintKey("test-machine1"),
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{"test-machine-group"},
+		},
+		{
+			Key:      "gpu.intel.com/device-id.0300-56a0.present",
+			Operator: corev1.NodeSelectorOpExists,
+		},
+	}
+	userTerm := corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key:      "example.com/zone",
+			Operator: corev1.NodeSelectorOpExists,
+		}},
+	}
+
+	tests := []struct {
+		description string
+		affinity    *corev1.Affinity
+		expected    []corev1.NodeSelectorTerm
+	}{
+		{
+			description: "Affinity is empty",
+			affinity:    nil,
+			expected:    []corev1.NodeSelectorTerm{{MatchExpressions: injected}},
+		},
+		{
+			description: "NodeAffinity is empty",
+			affinity: &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{},
+			},
+			expected: []corev1.NodeSelectorTerm{{MatchExpressions: injected}},
+		},
+		{
+			description: "Already injected term is not duplicated",
+			affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{userTerm, {MatchExpressions: injected}},
+					},
+				},
+			},
+			expected: []corev1.NodeSelectorTerm{userTerm, {MatchExpressions: injected}},
+		},
+		{
+			description: "Expressions with the same key but other operator or values are kept",
+			affinity: &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{
+									Key:      GenerateMachineTypeLabelTaintKey("test-machine1"),
+									Operator: corev1.NodeSelectorOpNotIn,
+									Values:   []string{"test-machine-group"},
+								},
+								{
+									Key:      GenerateMachineTypeLabelTaintKey("test-machine1"),
+									Operator: corev1.NodeSelectorOpIn,
+									Values:   []string{"test-machine-group", "other-machine-group"},
+								},
+							},
+						}},
+					},
+				},
+			},
+			expected: []corev1.NodeSelectorTerm{
+				{
+					MatchExpressions: []corev1.NodeSelectorRequirement{
+						{
+							Key:      GenerateMachineTypeLabelTaintKey("test-machine1"),
+							Operator: corev1.NodeSelectorOpNotIn,
+							Values:   []string{"test-machine-group"},
+						},
+						{
+							Key:      GenerateMachineTypeLabelTaintKey("test-machine1"),
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"test-machine-group", "other-machine-group"},
+						},
+					},
+				},
+				{MatchExpressions: injected},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			pod := &corev1.Pod{}
+			pod.Spec.Affinity = test.affinity
+			injectPodAffinity(pod, injected)
+			// injection must be idempotent since Pod templates and Pods made from them are injected.
+			injectPodAffinity(pod, injected)
+			actual := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+			if diff := cmp.Diff(actual, test.expected); diff != "" {
+				t.Fatalf("\ndiff: %v\n; actual and expected are different", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// EstimateMachineTypeCapacity packs units of machineTypes into the free resources of Nodes and returns
+// how many units of each machineType can be placed in total.
+// placed is the number of units already running for each machineType; those resources must be subtracted from nodeFree by callers.
+// nodeMachineTypes is machineType names that each Node serves.
+// Since machineTypes which share a Node compete for the same allocatable resources,
+// units are placed in round-robin order so that one machineType does not starve the others.
+// machineTypes whose unit requests no resources are not included in the returned map,
+// since the number of those units can not be bounded by resources.
+func EstimateMachineTypeCapacity(machineTypes []MachineType, placed map[string]int32,
+	nodeFree map[string]corev1.ResourceList, nodeMachineTypes map[string][]string) map[string]int32 {
+
+	free := make(map[string]corev1.ResourceList, len(nodeFree))
+	for name, rl := range nodeFree {
+		free[name] = rl.DeepCopy()
+	}
+
+	// machineType name -> sorted node names
+	machineTypeNodes := make(map[string][]string)
+	for nodeName, mtNames := range nodeMachineTypes {
+		for _, mtName := range mtNames {
+			machineTypeNodes[mtName] = append(machineTypeNodes[mtName], nodeName)
+		}
+	}
+	for mtName := range machineTypeNodes {
+		sort.Strings(machineTypeNodes[mtName])
+	}
+
+	var packedMachineTypes []MachineType
+	units := make(map[string]corev1.ResourceList, len(machineTypes))
+	capacity := make(map[string]int32, len(machineTypes))
+	for _, mt := range machineTypes {
+		unit := convertToResourceQuantity(&mt)
+		if isZeroResources(unit) {
+			continue
+		}
+		packedMachineTypes = append(packedMachineTypes, mt)
+		units[mt.Name] = unit
+		capacity[mt.Name] = placed[mt.Name]
+	}
+
+	packing := true
+	for packing {
+		packing = false
+		for _, mt := range packedMachineTypes {
+			if capacity[mt.Name] >= mt.Available {
+				continue
+			}
+			unit := units[mt.Name]
+			for _, nodeName := range machineTypeNodes[mt.Name] {
+				if !fitResources(unit, free[nodeName]) {
+					continue
+				}
+				subtractResources(free[nodeName], unit)
+				capacity[mt.Name]++
+				packing = true
+				break
+			}
+		}
+	}
+
+	return capacity
+}
+
+// CheckMachineTypeCapacity compares available of machineTypes with allocatable resources of Nodes in ready mode.
+// It returns an error if a machineType can not fit on any of its Nodes,
+// and returns warnings if available of a machineType is more than units which can be placed on its Nodes.
+// Nodes which have not reported allocatable resources yet are ignored,
+// and machineTypes with autoCapacity are not checked for oversubscription.
+func CheckMachineTypeCapacity(machineTypes []MachineType, nodePool []NodePool, nodes []corev1.Node) ([]string, error) {
+	nodeMap := make(map[string]*corev1.Node, len(nodes))
+	for idx := range nodes {
+		nodeMap[nodes[idx].Name] = &nodes[idx]
+	}
+	machineTypeMap := make(map[string]*MachineType, len(machineTypes))
+	for idx := range machineTypes {
+		machineTypeMap[machineTypes[idx].Name] = &machineTypes[idx]
+	}
+
+	candidates := make(map[string]bool)
+	fitting := make(map[string]bool)
+	unreported := make(map[string]bool)
+	nodeFree := make(map[string]corev1.ResourceList)
+	nodeMachineTypes := make(map[string][]string)
+	for _, np := range nodePool {
+		if np.Mode != NodeModeReady {
+			continue
+		}
+		node, exist := nodeMap[np.Name]
+		if !exist {
+			continue
+		}
+		for _, npmt := range np.MachineType {
+			mt, exist := machineTypeMap[npmt.Name]
+			if !exist {
+				continue
+			}
+			if len(node.Status.Allocatable) == 0 {
+				unreported[mt.Name] = true
+				continue
+			}
+			candidates[mt.Name] = true
+			if !matchGPULabel(mt, node) || !fitResources(convertToResourceQuantity(mt), node.Status.Allocatable) {
+				continue
+			}
+			fitting[mt.Name] = true
+			nodeFree[node.Name] = node.Status.Allocatable
+			nodeMachineTypes[node.Name] = append(nodeMachineTypes[node.Name], mt.Name)
+		}
+	}
+
+	var estimated []MachineType
+	for _, mt := range machineTypes {
+		if candidates[mt.Name] && !fitting[mt.Name] {
+			return nil, fmt.Errorf("machineType <%s> can not fit on any of its nodes", mt.Name)
+		}
+		if !mt.AutoCapacity && fitting[mt.Name] && !unreported[mt.Name] {
+			estimated = append(estimated, mt)
+		}
+	}
+
+	var warnings []string
+	capacity := EstimateMachineTypeCapacity(estimated, map[string]int32{}, nodeFree, nodeMachineTypes)
+	for _, mt := range estimated {
+		if c, exist := capacity[mt.Name]; exist && c < mt.Available {
+			warnings = append(warnings, fmt.Sprintf("machineType <%s> is oversubscribed; available is %d, but only %d fit on its nodes",
+				mt.Name, mt.Available, capacity[mt.Name]))
+		}
+	}
+	return warnings, nil
+}
+
+// matchGPULabel returns true if the Node has GPU labels selected by the machineType.
+func matchGPULabel(machineType *MachineType, node *corev1.Node) bool {
+	if machineType.Spec.GPU == nil {
+		return true
+	}
+	selector, err := NodeSelectorRequirementsAsSelector(generateGPUMatchExpression(*machineType.Spec.GPU))
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(node.Labels))
+}
+
+func fitResources(request, free corev1.ResourceList) bool {
+	for name, q := range request {
+		if q.IsZero() {
+			continue
+		}
+		f, exist := free[name]
+		if !exist || f.Cmp(q) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isZeroResources(rl corev1.ResourceList) bool {
+	for _, q := range rl {
+		if !q.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+func subtractResources(free, request corev1.ResourceList) {
+	for name, q := range request {
+		f, exist := free[name]
+		if !exist {
+			continue
+		}
+		f.Sub(q)
+		free[name] = f
+	}
+}
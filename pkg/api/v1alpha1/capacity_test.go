@@ -0,0 +1,317 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+func newFakeCapacityMachineType(name, cpu, memory string, available int32) MachineType {
+	return MachineType{
+		Name: name,
+		Spec: MachineDetailSpec{
+			CPU:    resource.MustParse(cpu),
+			Memory: resource.MustParse(memory),
+		},
+		Available: available,
+	}
+}
+
+func newFakeFreeResources(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func TestEstimateMachineTypeCapacity(t *testing.T) {
+	tests := []struct {
+		description      string
+		machineTypes     []MachineType
+		placed           map[string]int32
+		nodeFree         map[string]corev1.ResourceList
+		nodeMachineTypes map[string][]string
+		expected         map[string]int32
+	}{
+		{
+			description: "machineTypes share the allocatable resources of a Node",
+			machineTypes: []MachineType{
+				newFakeCapacityMachineType("compute-xlarge", "8", "32Gi", 2),
+				newFakeCapacityMachineType("compute-small", "2", "8Gi", 4),
+			},
+			nodeFree: map[string]corev1.ResourceList{
+				"node-a": newFakeFreeResources("16", "64Gi"),
+			},
+			nodeMachineTypes: map[string][]string{
+				"node-a": {"compute-xlarge", "compute-small"},
+			},
+			expected: map[string]int32{
+				"compute-xlarge": 1,
+				"compute-small":  4,
+			},
+		},
+		{
+			description: "Capacity does not exceed available",
+			machineTypes: []MachineType{
+				newFakeCapacityMachineType("compute-small", "2", "8Gi", 2),
+			},
+			nodeFree: map[string]corev1.ResourceList{
+				"node-a": newFakeFreeResources("16", "64Gi"),
+			},
+			nodeMachineTypes: map[string][]string{
+				"node-a": {"compute-small"},
+			},
+			expected: map[string]int32{
+				"compute-small": 2,
+			},
+		},
+		{
+			description: "Already placed units are counted",
+			machineTypes: []MachineType{
+				newFakeCapacityMachineType("compute-xlarge", "8", "32Gi", 2),
+				newFakeCapacityMachineType("compute-small", "2", "8Gi", 4),
+			},
+			placed: map[string]int32{
+				"compute-xlarge": 1,
+			},
+			nodeFree: map[string]corev1.ResourceList{
+				"node-a": newFakeFreeResources("8", "32Gi"),
+				"node-b": newFakeFreeResources("4", "16Gi"),
+			},
+			nodeMachineTypes: map[string][]string{
+				"node-a": {"compute-xlarge", "compute-small"},
+				"node-b": {"compute-small"},
+			},
+			expected: map[string]int32{
+				"compute-xlarge": 2,
+				"compute-small":  2,
+			},
+		},
+		{
+			description: "Node does not have enough resources",
+			machineTypes: []MachineType{
+				newFakeCapacityMachineType("compute-xlarge", "8", "32Gi", 2),
+			},
+			nodeFree: map[string]corev1.ResourceList{
+				"node-a": newFakeFreeResources("4", "64Gi"),
+			},
+			nodeMachineTypes: map[string][]string{
+				"node-a": {"compute-xlarge"},
+			},
+			expected: map[string]int32{
+				"compute-xlarge": 0,
+			},
+		},
+		{
+			description: "machineType which requests no resources is not estimated",
+			machineTypes: []MachineType{
+				newFakeCapacityMachineType("compute-empty", "0", "0", math.MaxInt32),
+				newFakeCapacityMachineType("compute-small", "2", "8Gi", 4),
+			},
+			nodeFree: map[string]corev1.ResourceList{
+				"node-a": newFakeFreeResources("16", "64Gi"),
+			},
+			nodeMachineTypes: map[string][]string{
+				"node-a": {"compute-empty", "compute-small"},
+			},
+			expected: map[string]int32{
+				"compute-small": 4,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			actual := EstimateMachineTypeCapacity(test.machineTypes, test.placed, test.nodeFree, test.nodeMachineTypes)
+			if diff := cmp.Diff(actual, test.expected); diff != "" {
+				t.Fatalf("\ndiff: %v\n; actual and expected are different", diff)
+			}
+		})
+	}
+}
+
+func TestCheckMachineTypeCapacity(t *testing.T) {
+	newFakeCapacityNode := func(name string, allocatable corev1.ResourceList, nodeLabels map[string]string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: nodeLabels,
+			},
+			Status: corev1.NodeStatus{
+				Allocatable: allocatable,
+			},
+		}
+	}
+	newFakeCapacityNodePool := func(name string, mode NodePoolMode, machineTypeNames ...string) NodePool {
+		np := NodePool{
+			Name: name,
+			Mode: mode,
+		}
+		for _, mtName := range machineTypeNames {
+			np.MachineType = append(np.MachineType, NodePoolMachineType{Name: mtName})
+		}
+		return np
+	}
+	gpuMachineType := newFakeCapacityMachineType("compute-gpu", "4", "16Gi", 1)
+	gpuMachineType.Spec.GPU = &GPUSpec{
+		Type:   "nvidia.com/gpu",
+		Num:    resource.MustParse("1"),
+		Family: "ampere",
+	}
+	autoMachineType := newFakeCapacityMachineType("compute-auto", "8", "32Gi", 0)
+	autoMachineType.AutoCapacity = true
+
+	tests := []struct {
+		description  string
+		machineTypes []MachineType
+		nodePool     []NodePool
+		nodes        []corev1.Node
+		warnings     []string
+		err          bool
+	}{
+		{
+			description:  "Available fits on Nodes",
+			machineTypes: []MachineType{newFakeCapacityMachineType("compute-small", "2", "8Gi", 4)},
+			nodePool:     []NodePool{newFakeCapacityNodePool("node-a", NodeModeReady, "compute-small")},
+			nodes:        []corev1.Node{newFakeCapacityNode("node-a", newFakeFreeResources("8", "32Gi"), nil)},
+		},
+		{
+			description:  "Available is oversubscribed",
+			machineTypes: []MachineType{newFakeCapacityMachineType("compute-small", "2", "8Gi", 5)},
+			nodePool:     []NodePool{newFakeCapacityNodePool("node-a", NodeModeReady, "compute-small")},
+			nodes:        []corev1.Node{newFakeCapacityNode("node-a", newFakeFreeResources("8", "32Gi"), nil)},
+			warnings:     []string{"machineType <compute-small> is oversubscribed; available is 5, but only 4 fit on its nodes"},
+		},
+		{
+			description:  "machineType can not fit on any Nodes",
+			machineTypes: []MachineType{newFakeCapacityMachineType("compute-xlarge", "16", "64Gi", 1)},
+			nodePool:     []NodePool{newFakeCapacityNodePool("node-a", NodeModeReady, "compute-xlarge")},
+			nodes:        []corev1.Node{newFakeCapacityNode("node-a", newFakeFreeResources("8", "32Gi"), nil)},
+			err:          true,
+		},
+		{
+			description:  "Nodes do not have GPU label",
+			machineTypes: []MachineType{gpuMachineType},
+			nodePool:     []NodePool{newFakeCapacityNodePool("node-a", NodeModeReady, "compute-gpu")},
+			nodes: []corev1.Node{newFakeCapacityNode("node-a", corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("32Gi"),
+				"nvidia.com/gpu":      resource.MustParse("1"),
+			}, map[string]string{consts.NvidiaGPUFamilyKey: "turing"})},
+			err: true,
+		},
+		{
+			description:  "Nodes have GPU label",
+			machineTypes: []MachineType{gpuMachineType},
+			nodePool:     []NodePool{newFakeCapacityNodePool("node-a", NodeModeReady, "compute-gpu")},
+			nodes: []corev1.Node{newFakeCapacityNode("node-a", corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("32Gi"),
+				"nvidia.com/gpu":      resource.MustParse("1"),
+			}, map[string]string{consts.NvidiaGPUFamilyKey: "ampere"})},
+		},
+		{
+			description: "machineTypes share the allocatable resources of a Node",
+			machineTypes: []MachineType{
+				newFakeCapacityMachineType("compute-xlarge", "8", "32Gi", 1),
+				newFakeCapacityMachineType("compute-small", "2", "8Gi", 4),
+			},
+			nodePool: []NodePool{newFakeCapacityNodePool("node-a", NodeModeReady, "compute-xlarge", "compute-small")},
+			nodes:    []corev1.Node{newFakeCapacityNode("node-a", newFakeFreeResources("8", "32Gi"), nil)},
+			warnings: []string{"machineType <compute-small> is oversubscribed; available is 4, but only 0 fit on its nodes"},
+		},
+		{
+			description: "Nodes match gpu.selector",
+			machineTypes: []MachineType{func() MachineType {
+				mt := newFakeCapacityMachineType("compute-amd", "4", "16Gi", 1)
+				mt.Spec.GPU = &GPUSpec{
+					Type: "amd.com/gpu",
+					Num:  resource.MustParse("1"),
+					Selector: []corev1.NodeSelectorRequirement{{
+						Key:      "amd.com/gpu.family",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{"AI"},
+					}},
+				}
+				return mt
+			}()},
+			nodePool: []NodePool{newFakeCapacityNodePool("node-a", NodeModeReady, "compute-amd")},
+			nodes: []corev1.Node{newFakeCapacityNode("node-a", corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("32Gi"),
+				"amd.com/gpu":         resource.MustParse("1"),
+			}, map[string]string{"amd.com/gpu.family": "AI"})},
+		},
+		{
+			description:  "Nodes in maintenance mode are not counted",
+			machineTypes: []MachineType{newFakeCapacityMachineType("compute-small", "2", "8Gi", 4)},
+			nodePool: []NodePool{
+				newFakeCapacityNodePool("node-a", NodeModeReady, "compute-small"),
+				newFakeCapacityNodePool("node-b", NodeModeMaintenance, "compute-small"),
+			},
+			nodes: []corev1.Node{
+				newFakeCapacityNode("node-a", newFakeFreeResources("4", "16Gi"), nil),
+				newFakeCapacityNode("node-b", newFakeFreeResources("4", "16Gi"), nil),
+			},
+			warnings: []string{"machineType <compute-small> is oversubscribed; available is 4, but only 2 fit on its nodes"},
+		},
+		{
+			description:  "Nodes which have not reported allocatable resources are ignored",
+			machineTypes: []MachineType{newFakeCapacityMachineType("compute-small", "2", "8Gi", 4)},
+			nodePool: []NodePool{
+				newFakeCapacityNodePool("node-a", NodeModeReady, "compute-small"),
+				newFakeCapacityNodePool("node-b", NodeModeReady, "compute-small"),
+			},
+			nodes: []corev1.Node{
+				newFakeCapacityNode("node-a", newFakeFreeResources("4", "16Gi"), nil),
+				newFakeCapacityNode("node-b", nil, nil),
+			},
+		},
+		{
+			description:  "machineType with autoCapacity is not oversubscribed",
+			machineTypes: []MachineType{autoMachineType},
+			nodePool:     []NodePool{newFakeCapacityNodePool("node-a", NodeModeReady, "compute-auto")},
+			nodes:        []corev1.Node{newFakeCapacityNode("node-a", newFakeFreeResources("16", "64Gi"), nil)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			warnings, err := CheckMachineTypeCapacity(test.machineTypes, test.nodePool, test.nodes)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error; %v", err)
+			}
+			if diff := cmp.Diff(warnings, test.warnings); diff != "" {
+				t.Fatalf("\ndiff: %v\n; actual and expected are different", diff)
+			}
+		})
+	}
+}
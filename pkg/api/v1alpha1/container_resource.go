@@ -0,0 +1,225 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+type containerRatio struct {
+	name   string
+	weight int64
+}
+
+// generateContainerResources returns resources for each container so that the total of the Pod is equal to resources of the machineType.
+// By default, all resources are injected into the container selected by findInjectingTargetContainerIndex.
+// Resources are always calculated from the machineType, so the result does not change when the webhook is reinvoked for injected Pods.
+func generateContainerResources(machineType *MachineType, pod *corev1.Pod) (map[string]corev1.ResourceRequirements, error) {
+	budget := GenerateResourceRequirements(machineType)
+
+	ratios, err := parseContainerResourceRatio(pod)
+	if err != nil {
+		return nil, err
+	}
+	if ratios == nil {
+		ratios = []containerRatio{{
+			name:   pod.Spec.Containers[findInjectingTargetContainerIndex(pod)].Name,
+			weight: 1,
+		}}
+	}
+
+	if pod.Annotations[consts.ImperatorSubtractSidecarResourcesKey] == "true" {
+		if err = subtractSidecarResources(&budget, pod, ratios); err != nil {
+			return nil, err
+		}
+	}
+
+	requests, err := splitResourceList(budget.Requests, ratios)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := splitResourceList(budget.Limits, ratios)
+	if err != nil {
+		return nil, err
+	}
+
+	containerResources := make(map[string]corev1.ResourceRequirements, len(ratios))
+	for _, r := range ratios {
+		containerResources[r.name] = corev1.ResourceRequirements{
+			Requests: requests[r.name],
+			Limits:   limits[r.name],
+		}
+	}
+	return containerResources, nil
+}
+
+// parseContainerResourceRatio parses the annotation formatted like "app=3,worker=1".
+func parseContainerResourceRatio(pod *corev1.Pod) ([]containerRatio, error) {
+	value, exist := pod.Annotations[consts.ImperatorContainerResourceRatioKey]
+	if !exist {
+		return nil, nil
+	}
+
+	containerNames := make(map[string]bool, len(pod.Spec.Containers))
+	for _, c := range pod.Spec.Containers {
+		containerNames[c.Name] = true
+	}
+
+	var ratios []containerRatio
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		kv := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid format <%s> in annotation <%s>; it must be <container>=<weight>", item, consts.ImperatorContainerResourceRatioKey)
+		}
+		name := strings.TrimSpace(kv[0])
+		weight, err := strconv.ParseInt(strings.TrimSpace(kv[1]), 10, 64)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("weight of container <%s> must be a positive integer in annotation <%s>", name, consts.ImperatorContainerResourceRatioKey)
+		}
+		if !containerNames[name] {
+			return nil, fmt.Errorf("failed to find container <%s> specified in annotation <%s>", name, consts.ImperatorContainerResourceRatioKey)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("container <%s> is duplicated in annotation <%s>", name, consts.ImperatorContainerResourceRatioKey)
+		}
+		seen[name] = true
+		ratios = append(ratios, containerRatio{name: name, weight: weight})
+	}
+	return ratios, nil
+}
+
+// subtractSidecarResources subtracts resources of containers other than injected ones from budget.
+// Init containers are not subtracted since those do not run with containers,
+// although each init container must fit in the machineType so that the Pod does not request more than reservation Pods.
+func subtractSidecarResources(budget *corev1.ResourceRequirements, pod *corev1.Pod, ratios []containerRatio) error {
+	injected := make(map[string]bool, len(ratios))
+	for _, r := range ratios {
+		injected[r.name] = true
+	}
+
+	for _, c := range pod.Spec.InitContainers {
+		for name, q := range c.Resources.Requests {
+			if b, exist := budget.Requests[name]; exist && q.Cmp(b) > 0 {
+				return fmt.Errorf("init container <%s> requests more <%s> than the machineType", c.Name, name)
+			}
+		}
+	}
+
+	for _, c := range pod.Spec.Containers {
+		if injected[c.Name] {
+			continue
+		}
+		if err := subtractResourceList(budget.Requests, c.Resources.Requests); err != nil {
+			return fmt.Errorf("failed to subtract requests of container <%s>: %v", c.Name, err)
+		}
+		subtractLimits(budget.Limits, c.Resources.Limits)
+	}
+
+	// sidecars often have larger limits than requests, so limits are not less than requests.
+	for name, l := range budget.Limits {
+		if r, exist := budget.Requests[name]; exist && l.Cmp(r) < 0 {
+			budget.Limits[name] = r.DeepCopy()
+		}
+	}
+	return nil
+}
+
+func subtractResourceList(budget, sidecar corev1.ResourceList) error {
+	for name, q := range sidecar {
+		b, exist := budget[name]
+		if !exist {
+			continue
+		}
+		b.Sub(q)
+		if b.Sign() < 0 || (b.IsZero() && isSplittableResource(name)) {
+			return fmt.Errorf("<%s> of the machineType is not enough", name)
+		}
+		budget[name] = b
+	}
+	return nil
+}
+
+func subtractLimits(budget, sidecar corev1.ResourceList) {
+	for name, q := range sidecar {
+		b, exist := budget[name]
+		if !exist {
+			continue
+		}
+		b.Sub(q)
+		budget[name] = b
+	}
+}
+
+// splitResourceList splits CPU and memory by weights. Other resources such as GPUs can not be split,
+// so those are given to the first container. Remainders of the division are also given to the first container.
+func splitResourceList(total corev1.ResourceList, ratios []containerRatio) (map[string]corev1.ResourceList, error) {
+	var sum int64
+	for _, r := range ratios {
+		sum += r.weight
+	}
+
+	split := make(map[string]corev1.ResourceList, len(ratios))
+	for _, r := range ratios {
+		split[r.name] = corev1.ResourceList{}
+	}
+	if total == nil {
+		return split, nil
+	}
+
+	first := ratios[0].name
+	for name, q := range total {
+		if !isSplittableResource(name) || len(ratios) == 1 {
+			split[first][name] = q.DeepCopy()
+			continue
+		}
+
+		value := q.Value()
+		if name == corev1.ResourceCPU {
+			value = q.MilliValue()
+		}
+		var allocated int64
+		for _, r := range ratios[1:] {
+			v := value * r.weight / sum
+			if v == 0 {
+				return nil, fmt.Errorf("<%s> for container <%s> is too small to split", name, r.name)
+			}
+			split[r.name][name] = newSplitQuantity(name, v, q.Format)
+			allocated += v
+		}
+		split[first][name] = newSplitQuantity(name, value-allocated, q.Format)
+	}
+	return split, nil
+}
+
+func isSplittableResource(name corev1.ResourceName) bool {
+	return name == corev1.ResourceCPU || name == corev1.ResourceMemory
+}
+
+func newSplitQuantity(name corev1.ResourceName, value int64, format resource.Format) resource.Quantity {
+	if name == corev1.ResourceCPU {
+		return *resource.NewMilliQuantity(value, format)
+	}
+	return *resource.NewQuantity(value, format)
+}
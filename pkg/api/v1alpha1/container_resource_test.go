@@ -0,0 +1,244 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+func newFakeContainerResourcePod(annotations map[string]string, containers ...corev1.Container) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: containers,
+		},
+	}
+}
+
+func newFakeResourceList(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func equalResourceList(actual, expected corev1.ResourceList) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	for name, q := range expected {
+		a, exist := actual[name]
+		if !exist || a.Cmp(q) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGenerateContainerResources(t *testing.T) {
+	gpuRequests := func(cpu, memory string) corev1.ResourceList {
+		rl := newFakeResourceList(cpu, memory)
+		rl["nvidia.com/gpu"] = resource.MustParse("1")
+		return rl
+	}
+	sidecar := corev1.Container{
+		Name: "istio-proxy",
+		Resources: corev1.ResourceRequirements{
+			Requests: newFakeResourceList("100m", "128Mi"),
+			Limits:   newFakeResourceList("500m", "512Mi"),
+		},
+	}
+
+	tests := []struct {
+		description   string
+		annotations   map[string]string
+		containers    []corev1.Container
+		initContainer *corev1.Container
+		expected      map[string]corev1.ResourceRequirements
+		err           bool
+	}{
+		{
+			description: "All resources are injected into the first container by default",
+			containers:  []corev1.Container{{Name: "app"}, sidecar},
+			expected: map[string]corev1.ResourceRequirements{
+				"app": {Requests: gpuRequests("2", "4Gi"), Limits: gpuRequests("2", "4Gi")},
+			},
+		},
+		{
+			description: "Resources of sidecars are subtracted",
+			annotations: map[string]string{consts.ImperatorSubtractSidecarResourcesKey: "true"},
+			containers:  []corev1.Container{{Name: "app"}, sidecar},
+			expected: map[string]corev1.ResourceRequirements{
+				"app": {Requests: gpuRequests("1900m", "3968Mi"), Limits: gpuRequests("1900m", "3968Mi")},
+			},
+		},
+		{
+			description: "Resources are split by weights",
+			annotations: map[string]string{consts.ImperatorContainerResourceRatioKey: "app=3, worker=1"},
+			containers:  []corev1.Container{{Name: "app"}, {Name: "worker"}},
+			expected: map[string]corev1.ResourceRequirements{
+				"app":    {Requests: gpuRequests("1500m", "3Gi"), Limits: gpuRequests("1500m", "3Gi")},
+				"worker": {Requests: newFakeResourceList("500m", "1Gi"), Limits: newFakeResourceList("500m", "1Gi")},
+			},
+		},
+		{
+			description: "Resources of sidecars are subtracted before splitting",
+			annotations: map[string]string{
+				consts.ImperatorContainerResourceRatioKey:   "app=1,worker=1",
+				consts.ImperatorSubtractSidecarResourcesKey: "true",
+			},
+			containers: []corev1.Container{{Name: "app"}, {Name: "worker"}, {
+				Name: "sidecar",
+				Resources: corev1.ResourceRequirements{
+					Requests: newFakeResourceList("1", "2Gi"),
+					Limits:   newFakeResourceList("1", "2Gi"),
+				},
+			}},
+			expected: map[string]corev1.ResourceRequirements{
+				"app":    {Requests: gpuRequests("500m", "1Gi"), Limits: gpuRequests("500m", "1Gi")},
+				"worker": {Requests: newFakeResourceList("500m", "1Gi"), Limits: newFakeResourceList("500m", "1Gi")},
+			},
+		},
+		{
+			description: "Sidecars request more than the machineType",
+			annotations: map[string]string{consts.ImperatorSubtractSidecarResourcesKey: "true"},
+			containers: []corev1.Container{{Name: "app"}, {
+				Name: "sidecar",
+				Resources: corev1.ResourceRequirements{
+					Requests: newFakeResourceList("2", "1Gi"),
+				},
+			}},
+			err: true,
+		},
+		{
+			description:   "Init container requests more than the machineType",
+			annotations:   map[string]string{consts.ImperatorSubtractSidecarResourcesKey: "true"},
+			containers:    []corev1.Container{{Name: "app"}},
+			initContainer: &corev1.Container{Name: "init", Resources: corev1.ResourceRequirements{Requests: newFakeResourceList("4", "1Gi")}},
+			err:           true,
+		},
+		{
+			description: "Container specified in the annotation does not exist",
+			annotations: map[string]string{consts.ImperatorContainerResourceRatioKey: "app=1,missing=1"},
+			containers:  []corev1.Container{{Name: "app"}},
+			err:         true,
+		},
+		{
+			description: "Weight must be a positive integer",
+			annotations: map[string]string{consts.ImperatorContainerResourceRatioKey: "app=0"},
+			containers:  []corev1.Container{{Name: "app"}},
+			err:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			pod := newFakeContainerResourcePod(test.annotations, test.containers...)
+			if test.initContainer != nil {
+				pod.Spec.InitContainers = []corev1.Container{*test.initContainer}
+			}
+			actual, err := generateContainerResources(newFakeInjectionPolicyMachineType(nil), pod)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(actual) != len(test.expected) {
+				t.Fatalf("\nactual: %v\nexpected: %v\n; actual and expected are different", actual, test.expected)
+			}
+			for name, e := range test.expected {
+				a := actual[name]
+				if !equalResourceList(a.Requests, e.Requests) || !equalResourceList(a.Limits, e.Limits) {
+					t.Fatalf("\ncontainer: %s\nactual: %v\nexpected: %v\n; actual and expected are different", name, a, e)
+				}
+			}
+		})
+	}
+}
+
+func TestInjectResourceReinvocation(t *testing.T) {
+	sidecar := corev1.Container{
+		Name: "istio-proxy",
+		Resources: corev1.ResourceRequirements{
+			Requests: newFakeResourceList("100m", "128Mi"),
+			Limits:   newFakeResourceList("500m", "512Mi"),
+		},
+	}
+	expected := newFakeResourceList("1900m", "3968Mi")
+	expected["nvidia.com/gpu"] = resource.MustParse("1")
+
+	tests := []struct {
+		description string
+		addSidecar  func(pod *corev1.Pod)
+	}{
+		{
+			description: "Sidecar is appended after the first invocation",
+			addSidecar: func(pod *corev1.Pod) {
+				pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+			},
+		},
+		{
+			description: "Sidecar is inserted before the injected container after the first invocation",
+			addSidecar: func(pod *corev1.Pod) {
+				pod.Spec.Containers = append([]corev1.Container{sidecar}, pod.Spec.Containers...)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			machineType := newFakeInjectionPolicyMachineType(nil)
+			pod := newFakeContainerResourcePod(map[string]string{consts.ImperatorSubtractSidecarResourcesKey: "true"}, corev1.Container{Name: "app"})
+			if err := injectResource(machineType, pod); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// other webhooks add the sidecar, then the webhook is reinvoked.
+			test.addSidecar(pod)
+			for i := 0; i < 2; i++ {
+				if err := injectResource(machineType, pod); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			for _, c := range pod.Spec.Containers {
+				switch c.Name {
+				case "app":
+					if !equalResourceList(c.Resources.Requests, expected) || !equalResourceList(c.Resources.Limits, expected) {
+						t.Fatalf("\nactual: %v\nexpected: %v\n; actual and expected are different", c.Resources, expected)
+					}
+				case sidecar.Name:
+					if !equalResourceList(c.Resources.Requests, sidecar.Resources.Requests) || !equalResourceList(c.Resources.Limits, sidecar.Resources.Limits) {
+						t.Fatalf("\nactual: %v\nexpected: %v\n; resources of the sidecar are changed", c.Resources, sidecar.Resources)
+					}
+				}
+			}
+		})
+	}
+}
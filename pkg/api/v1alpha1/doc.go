@@ -0,0 +1,20 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains API Schema definitions for the imperator v1alpha1 API group.
+// The groupName tag is read from this file by code-generator.
+// +groupName=imperator.tenzen-y.io
+package v1alpha1
@@ -34,4 +34,12 @@ var (
 	// AddToScheme adds the types in this group-version to the given scheme.
 
 	AddToScheme = SchemeBuilder.AddToScheme
+
+	// SchemeGroupVersion is used by the generated clientset in pkg/client.
+	SchemeGroupVersion = GroupVersion
 )
+
+// Resource takes an unqualified resource and returns a Group qualified GroupResource.
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
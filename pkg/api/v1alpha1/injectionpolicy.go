@@ -0,0 +1,49 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// GenerateResourceRequirements returns resources of Guest Pods and reservation Pods according to the injectionPolicy of machineType.
+func GenerateResourceRequirements(machineType *MachineType) corev1.ResourceRequirements {
+	requests := convertToResourceQuantity(machineType)
+	limits := requests.DeepCopy()
+
+	if machineType.InjectionPolicy == nil {
+		return corev1.ResourceRequirements{Requests: requests, Limits: limits}
+	}
+
+	switch machineType.InjectionPolicy.Type {
+	case InjectionPolicyRequestsOnly:
+		delete(limits, corev1.ResourceCPU)
+		delete(limits, corev1.ResourceMemory)
+	case InjectionPolicyBurstable:
+		if machineType.InjectionPolicy.LimitPercentage == nil {
+			break
+		}
+		pct := int64(*machineType.InjectionPolicy.LimitPercentage)
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			q := requests[name]
+			limits[name] = *resource.NewMilliQuantity(q.MilliValue()*pct/100, q.Format)
+		}
+	}
+
+	return corev1.ResourceRequirements{Requests: requests, Limits: limits}
+}
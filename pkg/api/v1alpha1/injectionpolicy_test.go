@@ -0,0 +1,117 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+)
+
+func newFakeInjectionPolicyMachineType(policy *InjectionPolicy) *MachineType {
+	return &MachineType{
+		Name: "test-machine1",
+		Spec: MachineDetailSpec{
+			CPU:    resource.MustParse("2"),
+			Memory: resource.MustParse("4Gi"),
+			GPU: &GPUSpec{
+				Type:   "nvidia.com/gpu",
+				Num:    resource.MustParse("1"),
+				Family: "ampere",
+			},
+		},
+		Available:       1,
+		InjectionPolicy: policy,
+	}
+}
+
+func TestGenerateResourceRequirements(t *testing.T) {
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("4Gi"),
+		"nvidia.com/gpu":      resource.MustParse("1"),
+	}
+
+	tests := []struct {
+		description string
+		policy      *InjectionPolicy
+		expected    corev1.ResourceRequirements
+	}{
+		{
+			description: "Limits are equal to requests without injectionPolicy",
+			expected: corev1.ResourceRequirements{
+				Requests: requests,
+				Limits:   requests,
+			},
+		},
+		{
+			description: "Limits are equal to requests in Guaranteed",
+			policy:      &InjectionPolicy{Type: InjectionPolicyGuaranteed},
+			expected: corev1.ResourceRequirements{
+				Requests: requests,
+				Limits:   requests,
+			},
+		},
+		{
+			description: "Only GPU limits are set in RequestsOnly",
+			policy:      &InjectionPolicy{Type: InjectionPolicyRequestsOnly},
+			expected: corev1.ResourceRequirements{
+				Requests: requests,
+				Limits: corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("1"),
+				},
+			},
+		},
+		{
+			description: "CPU and memory limits are multiplied by limitPercentage in Burstable",
+			policy:      &InjectionPolicy{Type: InjectionPolicyBurstable, LimitPercentage: pointer.Int32(150)},
+			expected: corev1.ResourceRequirements{
+				Requests: requests,
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("3"),
+					corev1.ResourceMemory: resource.MustParse("6Gi"),
+					"nvidia.com/gpu":      resource.MustParse("1"),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			actual := GenerateResourceRequirements(newFakeInjectionPolicyMachineType(test.policy))
+			for _, rl := range []struct {
+				actual, expected corev1.ResourceList
+			}{
+				{actual.Requests, test.expected.Requests},
+				{actual.Limits, test.expected.Limits},
+			} {
+				if len(rl.actual) != len(rl.expected) {
+					t.Fatalf("\nactual: %v\nexpected: %v\n; actual and expected are different", rl.actual, rl.expected)
+				}
+				for name, q := range rl.expected {
+					a, exist := rl.actual[name]
+					if !exist || a.Cmp(q) != 0 {
+						t.Fatalf("\ndiff: %v\n; actual and expected are different", cmp.Diff(a.String(), q.String()))
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/tenzen-y/imperator/pkg/api/v1beta1"
+)
+
+var _ conversion.Convertible = &Machine{}
+
+// ConvertTo converts this Machine to the Hub version (v1beta1).
+func (src *Machine) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Machine)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1beta1.MachineSpec{
+		NodePool:         convertNodePoolToV1beta1(src.Spec.NodePool),
+		NodeSelector:     convertNodePoolSelectorToV1beta1(src.Spec.NodeSelector),
+		NodeHealthPolicy: convertNodeHealthPolicyToV1beta1(src.Spec.NodeHealthPolicy),
+		ReservationMode:  v1beta1.ReservationMode(src.Spec.ReservationMode),
+		ReportNodeStatus: src.Spec.ReportNodeStatus,
+	}
+	for _, mt := range src.Spec.MachineTypes {
+		dst.Spec.MachineTypes = append(dst.Spec.MachineTypes, v1beta1.MachineType{
+			Name: mt.Name,
+			Spec: v1beta1.MachineDetailSpec{
+				CPU:    mt.Spec.CPU.DeepCopy(),
+				Memory: mt.Spec.Memory.DeepCopy(),
+				GPU:    convertGPUSpecToV1beta1(mt.Spec.GPU),
+			},
+			Available:       mt.Available,
+			AutoCapacity:    mt.AutoCapacity,
+			InjectionPolicy: convertInjectionPolicyToV1beta1(mt.InjectionPolicy),
+		})
+	}
+
+	dst.Status = v1beta1.MachineStatus{
+		Conditions: copyConditions(src.Status.Conditions),
+	}
+	for _, am := range src.Status.AvailableMachines {
+		condition := v1beta1.AvailableMachineCondition{
+			Name:  am.Name,
+			Usage: v1beta1.UsageCondition(am.Usage),
+		}
+		for _, nu := range am.NamespaceUsage {
+			condition.NamespaceUsage = append(condition.NamespaceUsage, v1beta1.NamespaceUsageCondition(nu))
+		}
+		dst.Status.AvailableMachines = append(dst.Status.AvailableMachines, condition)
+	}
+	for _, n := range src.Status.Nodes {
+		condition := v1beta1.NodeUsageCondition{
+			Name:      n.Name,
+			Condition: v1beta1.MachineNodeCondition(n.Condition),
+		}
+		for _, mt := range n.MachineTypes {
+			condition.MachineTypes = append(condition.MachineTypes, v1beta1.NodeMachineTypeUsage(mt))
+		}
+		dst.Status.Nodes = append(dst.Status.Nodes, condition)
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *Machine) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Machine)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = MachineSpec{
+		NodePool:         convertNodePoolFromV1beta1(src.Spec.NodePool),
+		NodeSelector:     convertNodePoolSelectorFromV1beta1(src.Spec.NodeSelector),
+		NodeHealthPolicy: convertNodeHealthPolicyFromV1beta1(src.Spec.NodeHealthPolicy),
+		ReservationMode:  ReservationMode(src.Spec.ReservationMode),
+		ReportNodeStatus: src.Spec.ReportNodeStatus,
+	}
+	for _, mt := range src.Spec.MachineTypes {
+		dst.Spec.MachineTypes = append(dst.Spec.MachineTypes, MachineType{
+			Name: mt.Name,
+			Spec: MachineDetailSpec{
+				CPU:    mt.Spec.CPU.DeepCopy(),
+				Memory: mt.Spec.Memory.DeepCopy(),
+				GPU:    convertGPUSpecFromV1beta1(mt.Spec.GPU),
+			},
+			Available:       mt.Available,
+			AutoCapacity:    mt.AutoCapacity,
+			InjectionPolicy: convertInjectionPolicyFromV1beta1(mt.InjectionPolicy),
+		})
+	}
+
+	dst.Status = MachineStatus{
+		Conditions: copyConditions(src.Status.Conditions),
+	}
+	for _, am := range src.Status.AvailableMachines {
+		condition := AvailableMachineCondition{
+			Name:  am.Name,
+			Usage: UsageCondition(am.Usage),
+		}
+		for _, nu := range am.NamespaceUsage {
+			condition.NamespaceUsage = append(condition.NamespaceUsage, NamespaceUsageCondition(nu))
+		}
+		dst.Status.AvailableMachines = append(dst.Status.AvailableMachines, condition)
+	}
+	for _, n := range src.Status.Nodes {
+		condition := NodeUsageCondition{
+			Name:      n.Name,
+			Condition: MachineNodeCondition(n.Condition),
+		}
+		for _, mt := range n.MachineTypes {
+			condition.MachineTypes = append(condition.MachineTypes, NodeMachineTypeUsage(mt))
+		}
+		dst.Status.Nodes = append(dst.Status.Nodes, condition)
+	}
+
+	return nil
+}
+
+func convertGPUSpecToV1beta1(gpu *GPUSpec) *v1beta1.GPUSpec {
+	if gpu == nil {
+		return nil
+	}
+	out := v1beta1.GPUSpec(*gpu.DeepCopy())
+	return &out
+}
+
+func convertGPUSpecFromV1beta1(gpu *v1beta1.GPUSpec) *GPUSpec {
+	if gpu == nil {
+		return nil
+	}
+	out := GPUSpec(*gpu.DeepCopy())
+	return &out
+}
+
+func convertInjectionPolicyToV1beta1(policy *InjectionPolicy) *v1beta1.InjectionPolicy {
+	if policy == nil {
+		return nil
+	}
+	out := &v1beta1.InjectionPolicy{
+		Type: v1beta1.InjectionPolicyType(policy.Type),
+	}
+	if policy.LimitPercentage != nil {
+		limitPercentage := *policy.LimitPercentage
+		out.LimitPercentage = &limitPercentage
+	}
+	return out
+}
+
+func convertInjectionPolicyFromV1beta1(policy *v1beta1.InjectionPolicy) *InjectionPolicy {
+	if policy == nil {
+		return nil
+	}
+	out := &InjectionPolicy{
+		Type: InjectionPolicyType(policy.Type),
+	}
+	if policy.LimitPercentage != nil {
+		limitPercentage := *policy.LimitPercentage
+		out.LimitPercentage = &limitPercentage
+	}
+	return out
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/tenzen-y/imperator/pkg/api/v1beta1"
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+func newFakeConversionMachine() *Machine {
+	return &Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-machine",
+			Labels: map[string]string{
+				consts.MachineGroupKey: "test-group",
+			},
+		},
+		Spec: MachineSpec{
+			NodePool: []NodePool{
+				{
+					Name:        "test-node1",
+					Mode:        NodeModeReady,
+					Taint:       true,
+					MachineType: []NodePoolMachineType{{Name: "test-machine1"}},
+				},
+				{
+					Name:        "test-node2",
+					Mode:        NodeModeMaintenance,
+					MachineType: []NodePoolMachineType{{Name: "test-machine2"}},
+					Drain: &DrainPolicy{
+						GracePeriodSeconds: pointer.Int64(30),
+						DeadlineSeconds:    pointer.Int64(600),
+					},
+				},
+			},
+			NodeSelector: []NodePoolSelector{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"node-role": "gpu"},
+					},
+					Mode:        NodeModeReady,
+					MachineType: []NodePoolMachineType{{Name: "test-machine2"}},
+				},
+			},
+			MachineTypes: []MachineType{
+				{
+					Name: "test-machine1",
+					Spec: MachineDetailSpec{
+						CPU:    resource.MustParse("2"),
+						Memory: resource.MustParse("8Gi"),
+					},
+					Available: 2,
+					InjectionPolicy: &InjectionPolicy{
+						Type:            InjectionPolicyBurstable,
+						LimitPercentage: pointer.Int32(150),
+					},
+				},
+				{
+					Name: "test-machine2",
+					Spec: MachineDetailSpec{
+						CPU:    resource.MustParse("8"),
+						Memory: resource.MustParse("32Gi"),
+						GPU: &GPUSpec{
+							Type:    "nvidia.com/gpu",
+							Num:     resource.MustParse("1"),
+							Product: "NVIDIA-GeForce-RTX-3080",
+							Selector: []corev1.NodeSelectorRequirement{{
+								Key:      "nvidia.com/gpu.memory",
+								Operator: corev1.NodeSelectorOpGt,
+								Values:   []string{"10000"},
+							}},
+						},
+					},
+					AutoCapacity: true,
+				},
+			},
+			NodeHealthPolicy: &NodeHealthPolicy{
+				UnhealthyConditions: []UnhealthyNodeCondition{
+					{Type: "KernelDeadlock", Status: corev1.ConditionTrue},
+				},
+				UnhealthyTaints: []string{"example.com/broken"},
+			},
+			ReservationMode:  ReservationModePreemptible,
+			ReportNodeStatus: true,
+		},
+		Status: MachineStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               ConditionReady,
+					Status:             metav1.ConditionTrue,
+					Reason:             "Available",
+					LastTransitionTime: metav1.Unix(1640995200, 0),
+				},
+			},
+			AvailableMachines: []AvailableMachineCondition{
+				{
+					Name:  "test-machine1",
+					Usage: UsageCondition{Maximum: 2, Reserved: 1, Used: 1},
+					NamespaceUsage: []NamespaceUsageCondition{
+						{Namespace: "test-ns", Used: 1},
+					},
+				},
+				{
+					Name:  "test-machine2",
+					Usage: UsageCondition{Maximum: 1, Waiting: 1},
+				},
+			},
+			Nodes: []NodeUsageCondition{
+				{
+					Name:      "test-node1",
+					Condition: NodeHealthy,
+					MachineTypes: []NodeMachineTypeUsage{
+						{Name: "test-machine1", Reserved: 1, Used: 1},
+					},
+				},
+				{
+					Name:      "test-node2",
+					Condition: NodeMaintenance,
+				},
+			},
+		},
+	}
+}
+
+func TestMachineConversion(t *testing.T) {
+	t.Run("v1alpha1 -> v1beta1 -> v1alpha1", func(t *testing.T) {
+		origin := newFakeConversionMachine()
+		hub := &v1beta1.Machine{}
+		if err := origin.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatalf("failed to convert to v1beta1: %v", err)
+		}
+		actual := &Machine{}
+		if err := actual.ConvertFrom(hub); err != nil {
+			t.Fatalf("failed to convert from v1beta1: %v", err)
+		}
+		if diff := cmp.Diff(actual, origin); diff != "" {
+			t.Fatalf("\ndiff: %v\n; actual and expected are different", diff)
+		}
+	})
+
+	t.Run("v1beta1 -> v1alpha1 -> v1beta1", func(t *testing.T) {
+		origin := &v1beta1.Machine{}
+		if err := newFakeConversionMachine().ConvertTo(origin); err != nil {
+			t.Fatalf("failed to convert to v1beta1: %v", err)
+		}
+		spoke := &Machine{}
+		if err := spoke.ConvertFrom(origin.DeepCopy()); err != nil {
+			t.Fatalf("failed to convert from v1beta1: %v", err)
+		}
+		actual := &v1beta1.Machine{}
+		if err := spoke.ConvertTo(actual); err != nil {
+			t.Fatalf("failed to convert to v1beta1: %v", err)
+		}
+		if diff := cmp.Diff(actual, origin); diff != "" {
+			t.Fatalf("\ndiff: %v\n; actual and expected are different", diff)
+		}
+	})
+}
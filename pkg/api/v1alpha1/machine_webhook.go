@@ -19,14 +19,17 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/tenzen-y/imperator/pkg/consts"
 )
@@ -41,6 +44,9 @@ var (
 func (r *Machine) SetupWebhookWithManager(signalHandler context.Context, mgr ctrl.Manager) error {
 	kubeReader = mgr.GetAPIReader()
 	ctx = signalHandler
+	mgr.GetWebhookServer().Register(consts.MachineValidatorPath, &webhook.Admission{
+		Handler: &machineValidator{},
+	})
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -74,30 +80,31 @@ func (r *Machine) Default() {
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 // +kubebuilder:webhook:path=/validate-imperator-tenzen-y-io-v1alpha1-machine,mutating=false,failurePolicy=fail,sideEffects=None,groups=imperator.tenzen-y.io,resources=machines,verbs=create;update,versions=v1alpha1,name=validator.machine.imperator.tenzen-y.io,admissionReviewVersions={v1,v1beta1}
 
-var _ webhook.Validator = &Machine{}
+// machineValidator validates Machines instead of webhook.Validator, so that it can return admission warnings.
+type machineValidator struct {
+	decoder *admission.Decoder
+}
 
-// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (r *Machine) ValidateCreate() error {
-	machinelog.Info("validate create", "name", r.Name)
-	if err := r.ValidateAllOperation(); err != nil {
-		return err
-	}
+func (v *machineValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
 	return nil
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *Machine) ValidateUpdate(old runtime.Object) error {
-	machinelog.Info("validate update", "name", r.Name)
-	if err := r.ValidateAllOperation(); err != nil {
-		return err
+func (v *machineValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	r := &Machine{}
+	if err := v.decoder.Decode(req, r); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
 	}
-	return nil
-}
+	machinelog.Info("validate "+strings.ToLower(string(req.Operation)), "name", r.Name)
 
-// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
-func (r *Machine) ValidateDelete() error {
-	machinelog.Info("validate delete", "name", r.Name)
-	return nil
+	if err := r.ValidateAllOperation(); err != nil {
+		return admission.Denied(err.Error())
+	}
+	warnings, err := r.ValidateCapacity()
+	if err != nil {
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
 }
 
 func (r *Machine) ValidateAllOperation() error {
@@ -107,12 +114,24 @@ func (r *Machine) ValidateAllOperation() error {
 	if err := r.ValidateNodeName(); err != nil {
 		return err
 	}
+	if err := r.ValidateNodeSelector(); err != nil {
+		return err
+	}
 	if err := r.ValidateNodePoolMachineTypeName(); err != nil {
 		return err
 	}
+	if err := r.ValidateSharedNode(); err != nil {
+		return err
+	}
 	if err := r.ValidateGPUSpec(); err != nil {
 		return err
 	}
+	if err := r.ValidateInjectionPolicy(); err != nil {
+		return err
+	}
+	if err := r.ValidateAutoCapacity(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -163,6 +182,21 @@ func (r *Machine) ValidateNodeName() error {
 	return nil
 }
 
+func (r *Machine) ValidateNodeSelector() error {
+	if len(r.Spec.NodePool) == 0 && len(r.Spec.NodeSelector) == 0 {
+		return fmt.Errorf("either spec.nodePool or spec.nodeSelector must be set")
+	}
+	for _, ns := range r.Spec.NodeSelector {
+		if ns.LabelSelector == nil {
+			return fmt.Errorf("nodeSelector.labelSelector must be set")
+		}
+		if _, err := metav1.LabelSelectorAsSelector(ns.LabelSelector); err != nil {
+			return fmt.Errorf("failed to parse nodeSelector.labelSelector; %v", err)
+		}
+	}
+	return nil
+}
+
 func (r *Machine) ValidateNodePoolMachineTypeName() error {
 
 	machineTypeMap := map[string]MachineType{}
@@ -174,20 +208,102 @@ func (r *Machine) ValidateNodePoolMachineTypeName() error {
 	}
 
 	for _, p := range r.Spec.NodePool {
-		if len(p.MachineType) != 1 {
-			// Support only one machineType in first release
-			return fmt.Errorf("<%s>; can not set multiple machineType in nodePool.machineType", p.Name)
+		if len(p.MachineType) == 0 {
+			return fmt.Errorf("<%s>; nodePool.machineType must have at least one machineType", p.Name)
 		}
+		nodePoolMachineTypes := map[string]bool{}
 		for _, mt := range p.MachineType {
 			if _, exist := machineTypeMap[mt.Name]; !exist {
 				return fmt.Errorf("%s was not found in spec.machineTypes", mt.Name)
 			}
+			if nodePoolMachineTypes[mt.Name] {
+				return fmt.Errorf("<%s>; machineType <%s> is duplicated in nodePool.machineType", p.Name, mt.Name)
+			}
+			nodePoolMachineTypes[mt.Name] = true
+		}
+	}
+
+	for _, ns := range r.Spec.NodeSelector {
+		if len(ns.MachineType) == 0 {
+			return fmt.Errorf("nodeSelector.machineType must have at least one machineType")
+		}
+		nodeSelectorMachineTypes := map[string]bool{}
+		for _, mt := range ns.MachineType {
+			if _, exist := machineTypeMap[mt.Name]; !exist {
+				return fmt.Errorf("%s was not found in spec.machineTypes", mt.Name)
+			}
+			if nodeSelectorMachineTypes[mt.Name] {
+				return fmt.Errorf("machineType <%s> is duplicated in nodeSelector.machineType", mt.Name)
+			}
+			nodeSelectorMachineTypes[mt.Name] = true
+		}
+	}
+
+	return nil
+}
+
+// ValidateSharedNode validates that machineTypes of other machineGroups sharing the same Node do not conflict,
+// since label and taint keys for machineType are generated from only machineType name.
+func (r *Machine) ValidateSharedNode() error {
+	machines := &MachineList{}
+	if err := kubeReader.List(ctx, machines, &client.ListOptions{}); err != nil {
+		return err
+	}
+	nodes := &corev1.NodeList{}
+	if err := kubeReader.List(ctx, nodes, &client.ListOptions{}); err != nil {
+		return err
+	}
+
+	// nodeName -> machineType name -> machineGroup
+	otherMachineTypes := map[string]map[string]string{}
+	for _, m := range machines.Items {
+		machineGroupName := m.Labels[consts.MachineGroupKey]
+		if m.Name == r.Name || machineGroupName == r.Labels[consts.MachineGroupKey] {
+			continue
+		}
+		otherNodePool, err := ResolveNodePool(m.Spec.NodePool, m.Spec.NodeSelector, nodes.Items)
+		if err != nil {
+			return err
+		}
+		for _, np := range otherNodePool {
+			if _, exist := otherMachineTypes[np.Name]; !exist {
+				otherMachineTypes[np.Name] = map[string]string{}
+			}
+			for _, mt := range np.MachineType {
+				otherMachineTypes[np.Name][mt.Name] = machineGroupName
+			}
+		}
+	}
+
+	nodePool, err := ResolveNodePool(r.Spec.NodePool, r.Spec.NodeSelector, nodes.Items)
+	if err != nil {
+		return err
+	}
+	for _, np := range nodePool {
+		for _, mt := range np.MachineType {
+			if machineGroupName, exist := otherMachineTypes[np.Name][mt.Name]; exist {
+				return fmt.Errorf("<%s>; machineType <%s> is already used by machineGroup <%s> on the same node", np.Name, mt.Name, machineGroupName)
+			}
 		}
 	}
 
 	return nil
 }
 
+// ValidateCapacity validates that machineTypes fit on allocatable resources of Nodes in ready mode.
+// It returns warnings if available of machineTypes is oversubscribed.
+func (r *Machine) ValidateCapacity() ([]string, error) {
+	nodes := &corev1.NodeList{}
+	if err := kubeReader.List(ctx, nodes, &client.ListOptions{}); err != nil {
+		return nil, err
+	}
+	nodePool, err := ResolveNodePool(r.Spec.NodePool, r.Spec.NodeSelector, nodes.Items)
+	if err != nil {
+		return nil, err
+	}
+	return CheckMachineTypeCapacity(r.Spec.MachineTypes, nodePool, nodes.Items)
+}
+
 func (r *Machine) ValidateGPUSpec() error {
 	for _, m := range r.Spec.MachineTypes {
 		if m.Spec.GPU == nil {
@@ -207,11 +323,41 @@ func (r *Machine) ValidateGPUSpec() error {
 			gpuSelectorTypes = append(gpuSelectorTypes, s)
 		}
 
-		if gpuSelectorTypes == nil {
-			return fmt.Errorf("you must set a value for either gpu.family, gpu.product or gpu.machine")
+		if gpuSelectorTypes == nil && len(m.Spec.GPU.Selector) == 0 {
+			return fmt.Errorf("you must set a value for either gpu.family, gpu.product, gpu.machine or gpu.selector")
 		} else if len(gpuSelectorTypes) > 1 {
 			return fmt.Errorf("only one GPU family, product or machine cane be set")
 		}
+		if _, err := NodeSelectorRequirementsAsSelector(m.Spec.GPU.Selector); err != nil {
+			return fmt.Errorf("failed to parse gpu.selector of machineType <%s>; %v", m.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Machine) ValidateInjectionPolicy() error {
+	for _, m := range r.Spec.MachineTypes {
+		if m.InjectionPolicy == nil {
+			continue
+		}
+		if m.InjectionPolicy.Type == InjectionPolicyBurstable && m.InjectionPolicy.LimitPercentage == nil {
+			return fmt.Errorf("injectionPolicy.limitPercentage must be set in <%s> of machineType <%s>", InjectionPolicyBurstable, m.Name)
+		}
+		if m.InjectionPolicy.Type != InjectionPolicyBurstable && m.InjectionPolicy.LimitPercentage != nil {
+			return fmt.Errorf("injectionPolicy.limitPercentage can be set only in <%s>; machineType <%s>", InjectionPolicyBurstable, m.Name)
+		}
+	}
+	return nil
+}
+
+func (r *Machine) ValidateAutoCapacity() error {
+	for _, m := range r.Spec.MachineTypes {
+		if !m.AutoCapacity {
+			continue
+		}
+		if isZeroResources(convertToResourceQuantity(&m)) {
+			return fmt.Errorf("machineType <%s> with autoCapacity must request cpu, memory or gpu", m.Name)
+		}
 	}
 	return nil
 }
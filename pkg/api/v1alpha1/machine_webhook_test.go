@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/tenzen-y/imperator/pkg/consts"
@@ -162,6 +163,33 @@ var _ = Describe("Machine Webhook", func() {
 				}(),
 				err: true,
 			},
+			{
+				description: "Node is shared with other machineGroup",
+				fakeMachine: newFakeMachine(),
+				kubeResources: func() []client.Object {
+					otherMachine := newFakeMachine()
+					otherMachine.Name = "other-machine"
+					otherMachine.Labels[consts.MachineGroupKey] = "other-machine-group"
+					otherMachine.Spec.MachineTypes[0].Name = "other-machine1"
+					otherMachine.Spec.MachineTypes[1].Name = "other-machine2"
+					for idx := range otherMachine.Spec.NodePool {
+						otherMachine.Spec.NodePool[idx].MachineType[0].Name = "other-" + otherMachine.Spec.NodePool[idx].MachineType[0].Name
+					}
+					return []client.Object{otherMachine}
+				}(),
+				err: false,
+			},
+			{
+				description: "machineType conflicts with other machineGroup on the same Node",
+				fakeMachine: newFakeMachine(),
+				kubeResources: func() []client.Object {
+					otherMachine := newFakeMachine()
+					otherMachine.Name = "other-machine"
+					otherMachine.Labels[consts.MachineGroupKey] = "other-machine-group"
+					return []client.Object{otherMachine}
+				}(),
+				err: true,
+			},
 			{
 				description: "Specified non exist node name to nodePool",
 				fakeMachine: func() *Machine {
@@ -171,6 +199,53 @@ var _ = Describe("Machine Webhook", func() {
 				}(),
 				err: true,
 			},
+			{
+				description: "Select nodes with nodeSelector",
+				fakeMachine: func() *Machine {
+					fakeMachine := newFakeMachine()
+					fakeMachine.Spec.NodePool = nil
+					fakeMachine.Spec.NodeSelector = []NodePoolSelector{{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"kubernetes.io/os": "linux"},
+						},
+						Mode: NodeModeReady,
+						MachineType: []NodePoolMachineType{{
+							Name: "test-machine1",
+						}},
+					}}
+					return fakeMachine
+				}(),
+				err: false,
+			},
+			{
+				description: "Neither nodePool nor nodeSelector is set",
+				fakeMachine: func() *Machine {
+					fakeMachine := newFakeMachine()
+					fakeMachine.Spec.NodePool = nil
+					return fakeMachine
+				}(),
+				err: true,
+			},
+			{
+				description: "Invalid labelSelector in nodeSelector",
+				fakeMachine: func() *Machine {
+					fakeMachine := newFakeMachine()
+					fakeMachine.Spec.NodeSelector = []NodePoolSelector{{
+						LabelSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{
+								Key:      "kubernetes.io/os",
+								Operator: "Invalid",
+							}},
+						},
+						Mode: NodeModeReady,
+						MachineType: []NodePoolMachineType{{
+							Name: "test-machine1",
+						}},
+					}}
+					return fakeMachine
+				}(),
+				err: true,
+			},
 			{
 				description: "Type of GPU must be set value",
 				fakeMachine: func() *Machine {
@@ -209,7 +284,36 @@ var _ = Describe("Machine Webhook", func() {
 				err: true,
 			},
 			{
-				description: "Support only one machineType in NodePool",
+				description: "Select GPU Nodes with gpu.selector",
+				fakeMachine: func() *Machine {
+					fakeMachine := newFakeMachine()
+					fakeMachine.Spec.MachineTypes[0].Spec.GPU = &GPUSpec{
+						Type: "amd.com/gpu",
+						Num:  resource.MustParse("1"),
+						Selector: []corev1.NodeSelectorRequirement{{
+							Key:      "amd.com/gpu.family",
+							Operator: corev1.NodeSelectorOpExists,
+						}},
+					}
+					return fakeMachine
+				}(),
+				err: false,
+			},
+			{
+				description: "Invalid operator in gpu.selector",
+				fakeMachine: func() *Machine {
+					fakeMachine := newFakeMachine()
+					fakeMachine.Spec.MachineTypes[0].Spec.GPU.Selector = []corev1.NodeSelectorRequirement{{
+						Key:      "amd.com/gpu.family",
+						Operator: "Equals",
+						Values:   []string{"AI"},
+					}}
+					return fakeMachine
+				}(),
+				err: true,
+			},
+			{
+				description: "Support multiple machineTypes in NodePool",
 				fakeMachine: func() *Machine {
 					fakeMachine := newFakeMachine()
 					fakeMachine.Spec.NodePool[0].MachineType = append(fakeMachine.Spec.NodePool[0].MachineType, NodePoolMachineType{
@@ -217,6 +321,24 @@ var _ = Describe("Machine Webhook", func() {
 					})
 					return fakeMachine
 				}(),
+				err: false,
+			},
+			{
+				description: "MachineType is duplicated in NodePool",
+				fakeMachine: func() *Machine {
+					fakeMachine := newFakeMachine()
+					fakeMachine.Spec.NodePool[0].MachineType = append(fakeMachine.Spec.NodePool[0].MachineType, fakeMachine.Spec.NodePool[0].MachineType[0])
+					return fakeMachine
+				}(),
+				err: true,
+			},
+			{
+				description: "NodePool does not have any machineType",
+				fakeMachine: func() *Machine {
+					fakeMachine := newFakeMachine()
+					fakeMachine.Spec.NodePool[0].MachineType = nil
+					return fakeMachine
+				}(),
 				err: true,
 			},
 			{
@@ -240,6 +362,40 @@ var _ = Describe("Machine Webhook", func() {
 				}(),
 				err: true,
 			},
+			{
+				description: "Burstable injectionPolicy with limitPercentage",
+				fakeMachine: func() *Machine {
+					fakeMachine := newFakeMachine()
+					fakeMachine.Spec.MachineTypes[0].InjectionPolicy = &InjectionPolicy{
+						Type:            InjectionPolicyBurstable,
+						LimitPercentage: pointer.Int32(150),
+					}
+					return fakeMachine
+				}(),
+				err: false,
+			},
+			{
+				description: "limitPercentage must be set in Burstable injectionPolicy",
+				fakeMachine: func() *Machine {
+					fakeMachine := newFakeMachine()
+					fakeMachine.Spec.MachineTypes[0].InjectionPolicy = &InjectionPolicy{Type: InjectionPolicyBurstable}
+					return fakeMachine
+				}(),
+				err: true,
+			},
+			{
+				description: "autoCapacity must request any resources",
+				fakeMachine: func() *Machine {
+					fakeMachine := newFakeMachine()
+					fakeMachine.Spec.MachineTypes[1].AutoCapacity = true
+					fakeMachine.Spec.MachineTypes[1].Spec = MachineDetailSpec{
+						CPU:    resource.MustParse("0"),
+						Memory: resource.MustParse("0"),
+					}
+					return fakeMachine
+				}(),
+				err: true,
+			},
 			{
 				description: "Not specified GPU",
 				fakeMachine: func() *Machine {
@@ -0,0 +1,273 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/tenzen-y/imperator/pkg/api/v1beta1"
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+var _ conversion.Convertible = &MachineNodePool{}
+
+// ConvertTo converts this MachineNodePool to the Hub version (v1beta1).
+// Since v1beta1 derives machineTypeStock from machineTypes of nodePool and nodeSelector,
+// machineTypeStock is kept in the annotation only if it is different from the derived one.
+func (src *MachineNodePool) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.MachineNodePool)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1beta1.MachineNodePoolSpec{
+		MachineGroupName: src.Spec.MachineGroupName,
+		NodePool:         convertNodePoolToV1beta1(src.Spec.NodePool),
+		NodeSelector:     convertNodePoolSelectorToV1beta1(src.Spec.NodeSelector),
+		NodeHealthPolicy: convertNodeHealthPolicyToV1beta1(src.Spec.NodeHealthPolicy),
+	}
+	dst.Status = v1beta1.MachineNodePoolStatus{
+		Conditions: copyConditions(src.Status.Conditions),
+	}
+	for _, c := range src.Status.NodePoolCondition {
+		dst.Status.NodePoolCondition = append(dst.Status.NodePoolCondition, v1beta1.NodePoolCondition{
+			Name:           c.Name,
+			NodeCondition:  v1beta1.MachineNodeCondition(c.NodeCondition),
+			Reason:         c.Reason,
+			DrainStartTime: c.DrainStartTime.DeepCopy(),
+		})
+	}
+
+	var stock []string
+	for _, mts := range src.Spec.MachineTypeStock {
+		stock = append(stock, mts.Name)
+	}
+	removeAnnotation(&dst.ObjectMeta, consts.MachineTypeStockKey)
+	if !equalStrings(stock, referredMachineTypes(src.Spec.NodePool, src.Spec.NodeSelector)) {
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string)
+		}
+		dst.Annotations[consts.MachineTypeStockKey] = strings.Join(stock, ",")
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *MachineNodePool) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.MachineNodePool)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = MachineNodePoolSpec{
+		MachineGroupName: src.Spec.MachineGroupName,
+		NodePool:         convertNodePoolFromV1beta1(src.Spec.NodePool),
+		NodeSelector:     convertNodePoolSelectorFromV1beta1(src.Spec.NodeSelector),
+		NodeHealthPolicy: convertNodeHealthPolicyFromV1beta1(src.Spec.NodeHealthPolicy),
+	}
+	dst.Status = MachineNodePoolStatus{
+		Conditions: copyConditions(src.Status.Conditions),
+	}
+	for _, c := range src.Status.NodePoolCondition {
+		dst.Status.NodePoolCondition = append(dst.Status.NodePoolCondition, NodePoolCondition{
+			Name:           c.Name,
+			NodeCondition:  MachineNodeCondition(c.NodeCondition),
+			Reason:         c.Reason,
+			DrainStartTime: c.DrainStartTime.DeepCopy(),
+		})
+	}
+
+	stock := referredMachineTypes(dst.Spec.NodePool, dst.Spec.NodeSelector)
+	if value, exist := dst.Annotations[consts.MachineTypeStockKey]; exist {
+		stock = nil
+		if value != "" {
+			stock = strings.Split(value, ",")
+		}
+		removeAnnotation(&dst.ObjectMeta, consts.MachineTypeStockKey)
+	}
+	for _, name := range stock {
+		dst.Spec.MachineTypeStock = append(dst.Spec.MachineTypeStock, NodePoolMachineTypeStock{Name: name})
+	}
+
+	return nil
+}
+
+// referredMachineTypes returns names of machineTypes which nodePool and nodeSelector refer to without duplicates.
+func referredMachineTypes(nodePool []NodePool, nodeSelector []NodePoolSelector) []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(machineTypes []NodePoolMachineType) {
+		for _, mt := range machineTypes {
+			if seen[mt.Name] {
+				continue
+			}
+			seen[mt.Name] = true
+			names = append(names, mt.Name)
+		}
+	}
+	for _, p := range nodePool {
+		add(p.MachineType)
+	}
+	for _, s := range nodeSelector {
+		add(s.MachineType)
+	}
+	return names
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func removeAnnotation(meta *metav1.ObjectMeta, key string) {
+	delete(meta.Annotations, key)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+}
+
+func copyConditions(conditions []metav1.Condition) []metav1.Condition {
+	if conditions == nil {
+		return nil
+	}
+	out := make([]metav1.Condition, len(conditions))
+	for i := range conditions {
+		conditions[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
+
+func convertNodePoolToV1beta1(nodePool []NodePool) []v1beta1.NodePool {
+	var out []v1beta1.NodePool
+	for _, p := range nodePool {
+		out = append(out, v1beta1.NodePool{
+			Name:        p.Name,
+			Mode:        v1beta1.NodePoolMode(p.Mode),
+			Taint:       p.Taint,
+			MachineType: convertNodePoolMachineTypeToV1beta1(p.MachineType),
+			Drain:       convertDrainPolicyToV1beta1(p.Drain),
+		})
+	}
+	return out
+}
+
+func convertNodePoolFromV1beta1(nodePool []v1beta1.NodePool) []NodePool {
+	var out []NodePool
+	for _, p := range nodePool {
+		out = append(out, NodePool{
+			Name:        p.Name,
+			Mode:        NodePoolMode(p.Mode),
+			Taint:       p.Taint,
+			MachineType: convertNodePoolMachineTypeFromV1beta1(p.MachineType),
+			Drain:       convertDrainPolicyFromV1beta1(p.Drain),
+		})
+	}
+	return out
+}
+
+func convertNodePoolSelectorToV1beta1(nodeSelector []NodePoolSelector) []v1beta1.NodePoolSelector {
+	var out []v1beta1.NodePoolSelector
+	for _, s := range nodeSelector {
+		out = append(out, v1beta1.NodePoolSelector{
+			LabelSelector: s.LabelSelector.DeepCopy(),
+			Mode:          v1beta1.NodePoolMode(s.Mode),
+			Taint:         s.Taint,
+			MachineType:   convertNodePoolMachineTypeToV1beta1(s.MachineType),
+			Drain:         convertDrainPolicyToV1beta1(s.Drain),
+		})
+	}
+	return out
+}
+
+func convertNodePoolSelectorFromV1beta1(nodeSelector []v1beta1.NodePoolSelector) []NodePoolSelector {
+	var out []NodePoolSelector
+	for _, s := range nodeSelector {
+		out = append(out, NodePoolSelector{
+			LabelSelector: s.LabelSelector.DeepCopy(),
+			Mode:          NodePoolMode(s.Mode),
+			Taint:         s.Taint,
+			MachineType:   convertNodePoolMachineTypeFromV1beta1(s.MachineType),
+			Drain:         convertDrainPolicyFromV1beta1(s.Drain),
+		})
+	}
+	return out
+}
+
+func convertNodePoolMachineTypeToV1beta1(machineTypes []NodePoolMachineType) []v1beta1.NodePoolMachineType {
+	var out []v1beta1.NodePoolMachineType
+	for _, mt := range machineTypes {
+		out = append(out, v1beta1.NodePoolMachineType(mt))
+	}
+	return out
+}
+
+func convertNodePoolMachineTypeFromV1beta1(machineTypes []v1beta1.NodePoolMachineType) []NodePoolMachineType {
+	var out []NodePoolMachineType
+	for _, mt := range machineTypes {
+		out = append(out, NodePoolMachineType(mt))
+	}
+	return out
+}
+
+func convertDrainPolicyToV1beta1(drain *DrainPolicy) *v1beta1.DrainPolicy {
+	if drain == nil {
+		return nil
+	}
+	out := v1beta1.DrainPolicy(*drain.DeepCopy())
+	return &out
+}
+
+func convertDrainPolicyFromV1beta1(drain *v1beta1.DrainPolicy) *DrainPolicy {
+	if drain == nil {
+		return nil
+	}
+	out := DrainPolicy(*drain.DeepCopy())
+	return &out
+}
+
+func convertNodeHealthPolicyToV1beta1(policy *NodeHealthPolicy) *v1beta1.NodeHealthPolicy {
+	if policy == nil {
+		return nil
+	}
+	out := &v1beta1.NodeHealthPolicy{
+		UnhealthyTaints: append([]string(nil), policy.UnhealthyTaints...),
+	}
+	for _, c := range policy.UnhealthyConditions {
+		out.UnhealthyConditions = append(out.UnhealthyConditions, v1beta1.UnhealthyNodeCondition(c))
+	}
+	return out
+}
+
+func convertNodeHealthPolicyFromV1beta1(policy *v1beta1.NodeHealthPolicy) *NodeHealthPolicy {
+	if policy == nil {
+		return nil
+	}
+	out := &NodeHealthPolicy{
+		UnhealthyTaints: append([]string(nil), policy.UnhealthyTaints...),
+	}
+	for _, c := range policy.UnhealthyConditions {
+		out.UnhealthyConditions = append(out.UnhealthyConditions, UnhealthyNodeCondition(c))
+	}
+	return out
+}
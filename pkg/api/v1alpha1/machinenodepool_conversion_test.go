@@ -0,0 +1,156 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/tenzen-y/imperator/pkg/api/v1beta1"
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+func newFakeConversionMachineNodePool(machineTypeStock []string) *MachineNodePool {
+	drainStartTime := metav1.Unix(1640995200, 0)
+	pool := &MachineNodePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-group-node-pool",
+			Labels: map[string]string{
+				consts.MachineGroupKey: "test-group",
+			},
+		},
+		Spec: MachineNodePoolSpec{
+			MachineGroupName: "test-group",
+			NodePool: []NodePool{
+				{
+					Name:        "test-node1",
+					Mode:        NodeModeReady,
+					Taint:       true,
+					MachineType: []NodePoolMachineType{{Name: "test-machine1"}},
+				},
+				{
+					Name:        "test-node2",
+					Mode:        NodeModeMaintenance,
+					MachineType: []NodePoolMachineType{{Name: "test-machine1"}, {Name: "test-machine2"}},
+					Drain: &DrainPolicy{
+						DeadlineSeconds: pointer.Int64(600),
+					},
+				},
+			},
+			NodeSelector: []NodePoolSelector{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "node-role", Operator: metav1.LabelSelectorOpExists},
+						},
+					},
+					Mode:        NodeModeReady,
+					MachineType: []NodePoolMachineType{{Name: "test-machine2"}},
+				},
+			},
+			NodeHealthPolicy: &NodeHealthPolicy{
+				UnhealthyConditions: []UnhealthyNodeCondition{
+					{Type: "KernelDeadlock", Status: corev1.ConditionTrue},
+				},
+			},
+		},
+		Status: MachineNodePoolStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               ConditionReady,
+					Status:             metav1.ConditionTrue,
+					Reason:             "Available",
+					LastTransitionTime: metav1.Unix(1640995200, 0),
+				},
+			},
+			NodePoolCondition: []NodePoolCondition{
+				{Name: "test-node1", NodeCondition: NodeHealthy},
+				{Name: "test-node2", NodeCondition: NodeDraining, DrainStartTime: &drainStartTime},
+			},
+		},
+	}
+	for _, name := range machineTypeStock {
+		pool.Spec.MachineTypeStock = append(pool.Spec.MachineTypeStock, NodePoolMachineTypeStock{Name: name})
+	}
+	return pool
+}
+
+func TestMachineNodePoolConversion(t *testing.T) {
+	tests := []struct {
+		description      string
+		machineTypeStock []string
+		expectAnnotation bool
+	}{
+		{
+			description:      "machineTypeStock is the same as machineTypes of nodePool and nodeSelector",
+			machineTypeStock: []string{"test-machine1", "test-machine2"},
+			expectAnnotation: false,
+		},
+		{
+			description:      "machineTypeStock has machineType which no Node serves",
+			machineTypeStock: []string{"test-machine1", "test-machine2", "test-machine3"},
+			expectAnnotation: true,
+		},
+		{
+			description:      "machineTypeStock is in a different order",
+			machineTypeStock: []string{"test-machine2", "test-machine1"},
+			expectAnnotation: true,
+		},
+		{
+			description:      "machineTypeStock is empty",
+			machineTypeStock: nil,
+			expectAnnotation: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			origin := newFakeConversionMachineNodePool(test.machineTypeStock)
+			hub := &v1beta1.MachineNodePool{}
+			if err := origin.DeepCopy().ConvertTo(hub); err != nil {
+				t.Fatalf("failed to convert to v1beta1: %v", err)
+			}
+			if _, exist := hub.Annotations[consts.MachineTypeStockKey]; exist != test.expectAnnotation {
+				t.Fatalf("annotation <%s> exists: %v, expected: %v", consts.MachineTypeStockKey, exist, test.expectAnnotation)
+			}
+
+			actual := &MachineNodePool{}
+			if err := actual.ConvertFrom(hub.DeepCopy()); err != nil {
+				t.Fatalf("failed to convert from v1beta1: %v", err)
+			}
+			if diff := cmp.Diff(actual, origin); diff != "" {
+				t.Fatalf("\ndiff: %v\n; actual and expected are different", diff)
+			}
+
+			spoke := &MachineNodePool{}
+			if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+				t.Fatalf("failed to convert from v1beta1: %v", err)
+			}
+			actualHub := &v1beta1.MachineNodePool{}
+			if err := spoke.ConvertTo(actualHub); err != nil {
+				t.Fatalf("failed to convert to v1beta1: %v", err)
+			}
+			if diff := cmp.Diff(actualHub, hub); diff != "" {
+				t.Fatalf("\ndiff: %v\n; actual and expected are different", diff)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,12 +29,20 @@ type MachineNodePoolSpec struct {
 	MachineGroupName string `json:"machineGroupName"`
 
 	// NodePool is node list that machineGroup is managing.
-	// +kubebuilder:validation:Required
-	NodePool []NodePool `json:"nodePool"`
+	// +optional
+	NodePool []NodePool `json:"nodePool,omitempty"`
+
+	// NodeSelector selects nodes that machineGroup is managing by labels.
+	// +optional
+	NodeSelector []NodePoolSelector `json:"nodeSelector,omitempty"`
 
 	// MachineTypeStock is available machineType list.
 	// +kubebuilder:validation:Required
 	MachineTypeStock []NodePoolMachineTypeStock `json:"machineTypeStock"`
+
+	// NodeHealthPolicy is additional conditions and taints to treat nodes as not-ready.
+	// +optional
+	NodeHealthPolicy *NodeHealthPolicy `json:"nodeHealthPolicy,omitempty"`
 }
 
 type NodePool struct {
@@ -51,6 +60,75 @@ type NodePool struct {
 
 	// +kubebuilder:validation:Required
 	MachineType []NodePoolMachineType `json:"machineType"`
+
+	// Drain evicts Guest Pods from the Node when mode is maintenance.
+	// +optional
+	Drain *DrainPolicy `json:"drain,omitempty"`
+}
+
+// NodePoolSelector is used to add all Nodes matching labelSelector to the pool.
+type NodePoolSelector struct {
+
+	// +kubebuilder:validation:Required
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=ready;maintenance
+	Mode NodePoolMode `json:"mode"`
+
+	// +optional
+	// default=false
+	Taint bool `json:"taint,omitempty"`
+
+	// +kubebuilder:validation:Required
+	MachineType []NodePoolMachineType `json:"machineType"`
+
+	// Drain evicts Guest Pods from the Node when mode is maintenance.
+	// +optional
+	Drain *DrainPolicy `json:"drain,omitempty"`
+}
+
+// DrainPolicy defines how to evict Guest Pods from Nodes in maintenance mode.
+// Guest Pods are evicted through the Eviction API, so PodDisruptionBudgets are respected until DeadlineSeconds.
+type DrainPolicy struct {
+
+	// GracePeriodSeconds is the grace period for evicted Guest Pods.
+	// If it is not set, terminationGracePeriodSeconds of the Pod is used.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// DeadlineSeconds is how long to wait for PodDisruptionBudgets to allow evictions.
+	// Remaining Guest Pods are deleted without the Eviction API after the deadline,
+	// so PodDisruptionBudgets are not honoured after the deadline. If it is not set, Guest Pods are evicted forever.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	DeadlineSeconds *int64 `json:"deadlineSeconds,omitempty"`
+}
+
+// NodeHealthPolicy defines conditions and taints to treat nodes as not-ready.
+// Those are added to the built-in policy; Ready is False or Unknown, MemoryPressure, DiskPressure or PIDPressure is True,
+// and the Node has node.kubernetes.io/unschedulable or node.kubernetes.io/network-unavailable taint.
+type NodeHealthPolicy struct {
+
+	// UnhealthyConditions is Node conditions to treat nodes as not-ready.
+	// e.g. conditions reported by node-problem-detector.
+	// +optional
+	UnhealthyConditions []UnhealthyNodeCondition `json:"unhealthyConditions,omitempty"`
+
+	// UnhealthyTaints is taint keys to treat nodes as not-ready.
+	// +optional
+	UnhealthyTaints []string `json:"unhealthyTaints,omitempty"`
+}
+
+type UnhealthyNodeCondition struct {
+
+	// +kubebuilder:validation:Required
+	Type corev1.NodeConditionType `json:"type"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status corev1.ConditionStatus `json:"status"`
 }
 
 type NodePoolMachineType struct {
@@ -94,22 +172,38 @@ type NodePoolCondition struct {
 
 	// +optional
 	NodeCondition MachineNodeCondition `json:"condition,omitempty"`
+
+	// Reason is why the Node is unhealthy.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// DrainStartTime is when imperator started to drain Guest Pods from the Node.
+	// +optional
+	DrainStartTime *metav1.Time `json:"drainStartTime,omitempty"`
 }
 
 // MachineNodeCondition is condition of Kubernetes Nodes
-// +kubebuilder:validation:Enum=Healthy;Maintenance;Unhealthy
+// +kubebuilder:validation:Enum=Healthy;Maintenance;Unhealthy;Missing;Draining;Drained
 type MachineNodeCondition string
 
 const (
 	NodeHealthy     MachineNodeCondition = "Healthy"
 	NodeMaintenance MachineNodeCondition = "Maintenance"
 	NodeUnhealthy   MachineNodeCondition = "Unhealthy"
+	// NodeMissing means the Node specified in nodePool does not exist in the cluster.
+	NodeMissing MachineNodeCondition = "Missing"
+	// NodeDraining means the Node is in maintenance mode, and Guest Pods are being evicted.
+	NodeDraining MachineNodeCondition = "Draining"
+	// NodeDrained means the Node is in maintenance mode, and all Guest Pods were evicted.
+	NodeDrained MachineNodeCondition = "Drained"
 )
 
 const (
 	ConditionReady = "Ready"
 )
 
+// +genclient
+// +genclient:nonNamespaced
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
@@ -0,0 +1,55 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/tenzen-y/imperator/pkg/api/v1beta1"
+)
+
+var _ conversion.Convertible = &MachineQuota{}
+
+// ConvertTo converts this MachineQuota to the Hub version (v1beta1).
+func (src *MachineQuota) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.MachineQuota)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1beta1.MachineQuotaSpec{
+		MachineGroup: src.Spec.MachineGroup,
+	}
+	for _, q := range src.Spec.Hard {
+		dst.Spec.Hard = append(dst.Spec.Hard, v1beta1.MachineTypeQuota(q))
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *MachineQuota) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.MachineQuota)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = MachineQuotaSpec{
+		MachineGroup: src.Spec.MachineGroup,
+	}
+	for _, q := range src.Spec.Hard {
+		dst.Spec.Hard = append(dst.Spec.Hard, MachineTypeQuota(q))
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MachineQuotaSpec defines the desired state of MachineQuota
+type MachineQuotaSpec struct {
+
+	// MachineGroup is the name of machineGroup which the quota is applied to.
+	// +kubebuilder:validation:Required
+	MachineGroup string `json:"machineGroup"`
+
+	// Hard is the maximum number of machineType units that the namespace may use.
+	// +kubebuilder:validation:Required
+	Hard []MachineTypeQuota `json:"hard"`
+}
+
+type MachineTypeQuota struct {
+
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum:=0
+	Max int32 `json:"max"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Group",type="string",JSONPath=`.spec.machineGroup`
+
+// MachineQuota is the Schema for the machinequotas API
+type MachineQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MachineQuotaSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MachineQuotaList contains a list of MachineQuota
+type MachineQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MachineQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MachineQuota{}, &MachineQuotaList{})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ResolveNodePool returns nodePool expanded with Nodes matching nodeSelector.
+// If a Node is specified in nodePool explicitly, that entry takes precedence over nodeSelector.
+// If a Node matches multiple nodeSelectors, the first matching entry is used.
+func ResolveNodePool(nodePool []NodePool, nodeSelector []NodePoolSelector, nodes []corev1.Node) ([]NodePool, error) {
+	resolved := make([]NodePool, 0, len(nodePool))
+	nodePoolNames := make(map[string]bool, len(nodePool))
+	for _, np := range nodePool {
+		nodePoolNames[np.Name] = true
+		resolved = append(resolved, *np.DeepCopy())
+	}
+
+	var selected []NodePool
+	for _, ns := range nodeSelector {
+		selector, err := metav1.LabelSelectorAsSelector(ns.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse nodeSelector.labelSelector; %v", err)
+		}
+		for _, n := range nodes {
+			if nodePoolNames[n.Name] || !selector.Matches(labels.Set(n.Labels)) {
+				continue
+			}
+			nodePoolNames[n.Name] = true
+			selected = append(selected, NodePool{
+				Name:        n.Name,
+				Mode:        ns.Mode,
+				Taint:       ns.Taint,
+				MachineType: append([]NodePoolMachineType{}, ns.MachineType...),
+				Drain:       ns.Drain.DeepCopy(),
+			})
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Name < selected[j].Name
+	})
+
+	return append(resolved, selected...), nil
+}
+
+// MatchNodeSelector checks whether the Node matches any of nodeSelector.
+func MatchNodeSelector(nodeSelector []NodePoolSelector, node *corev1.Node) bool {
+	for _, ns := range nodeSelector {
+		selector, err := metav1.LabelSelectorAsSelector(ns.LabelSelector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(node.Labels)) {
+			return true
+		}
+	}
+	return false
+}
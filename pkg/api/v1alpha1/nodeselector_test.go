@@ -0,0 +1,109 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newFakeLabeledNode(name string, nodeLabels map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: nodeLabels,
+		},
+	}
+}
+
+func TestResolveNodePool(t *testing.T) {
+	nodes := []corev1.Node{
+		newFakeLabeledNode("node-a", map[string]string{"pool": "gpu"}),
+		newFakeLabeledNode("node-b", map[string]string{"pool": "gpu"}),
+		newFakeLabeledNode("node-c", map[string]string{"pool": "cpu"}),
+	}
+
+	tests := []struct {
+		description  string
+		nodePool     []NodePool
+		nodeSelector []NodePoolSelector
+		expected     []NodePool
+		err          bool
+	}{
+		{
+			description: "Nodes matching nodeSelector join the pool",
+			nodeSelector: []NodePoolSelector{{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}},
+				Mode:          NodeModeReady,
+				Taint:         true,
+				MachineType:   []NodePoolMachineType{{Name: "compute-xlarge"}},
+			}},
+			expected: []NodePool{
+				{Name: "node-a", Mode: NodeModeReady, Taint: true, MachineType: []NodePoolMachineType{{Name: "compute-xlarge"}}},
+				{Name: "node-b", Mode: NodeModeReady, Taint: true, MachineType: []NodePoolMachineType{{Name: "compute-xlarge"}}},
+			},
+		},
+		{
+			description: "nodePool takes precedence over nodeSelector",
+			nodePool: []NodePool{
+				{Name: "node-b", Mode: NodeModeMaintenance, MachineType: []NodePoolMachineType{{Name: "compute-small"}}},
+			},
+			nodeSelector: []NodePoolSelector{{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}},
+				Mode:          NodeModeReady,
+				MachineType:   []NodePoolMachineType{{Name: "compute-xlarge"}},
+			}},
+			expected: []NodePool{
+				{Name: "node-b", Mode: NodeModeMaintenance, MachineType: []NodePoolMachineType{{Name: "compute-small"}}},
+				{Name: "node-a", Mode: NodeModeReady, MachineType: []NodePoolMachineType{{Name: "compute-xlarge"}}},
+			},
+		},
+		{
+			description: "Invalid labelSelector",
+			nodeSelector: []NodePoolSelector{{
+				LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "pool",
+					Operator: "Invalid",
+				}}},
+				Mode:        NodeModeReady,
+				MachineType: []NodePoolMachineType{{Name: "compute-xlarge"}},
+			}},
+			err: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			actual, err := ResolveNodePool(test.nodePool, test.nodeSelector, nodes)
+			if test.err {
+				if err == nil {
+					t.Fatal("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(actual, test.expected); diff != "" {
+				t.Fatalf("\ndiff: %v\n; actual and expected are different", diff)
+			}
+		})
+	}
+}
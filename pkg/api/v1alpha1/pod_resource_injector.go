@@ -21,10 +21,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -32,21 +36,40 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/tenzen-y/imperator/pkg/consts"
+	"github.com/tenzen-y/imperator/pkg/metrics"
 )
 
 var priLogger = ctrl.Log.WithName("pod-resource-injector")
 
 // +kubebuilder:webhook:path=/mutate-core-v1-pod,matchPolicy=equivalent,mutating=true,failurePolicy=fail,sideEffects=None,groups=core,resources=pods,verbs=create;update,versions=v1,name=mutator.pod.imperator.tenzen-y.io,admissionReviewVersions={v1,v1beta1}
+// +kubebuilder:rbac:groups=imperator.tenzen-y.io,resources=machinequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// NamespacePolicy is how to handle Guest Pods in namespaces without the label, imperator.tenzen.io/inject-resource=enabled.
+type NamespacePolicy string
+
+const (
+	// NamespacePolicyDeny rejects Guest Pods.
+	NamespacePolicyDeny NamespacePolicy = "Deny"
+	// NamespacePolicyIgnore admits Guest Pods without injection.
+	NamespacePolicyIgnore NamespacePolicy = "Ignore"
+)
 
-func NewResourceInjector(c client.Client) *resourceInjector {
+func NewResourceInjector(c client.Client, namespacePolicy NamespacePolicy, checkMachineAccess bool) *resourceInjector {
 	return &resourceInjector{
-		Client: c,
+		Client:             c,
+		NamespacePolicy:    namespacePolicy,
+		CheckMachineAccess: checkMachineAccess,
 	}
 }
 
 type resourceInjector struct {
-	Client  client.Client
-	decoder *admission.Decoder
+	Client          client.Client
+	NamespacePolicy NamespacePolicy
+	// CheckMachineAccess requires the verb, use for machines named after machine-group to create Guest Pods.
+	CheckMachineAccess bool
+	decoder            *admission.Decoder
 }
 
 func (r *resourceInjector) InjectDecoder(d *admission.Decoder) error {
@@ -60,17 +83,61 @@ func (r *resourceInjector) Handle(ctx context.Context, req admission.Request) ad
 		return admission.Errored(http.StatusInternalServerError, err)
 	}
 
-	// Inject resource to Pod
-	if r.requiredInjection(pod) {
+	if req.Operation == admissionv1.Update {
+		oldPod := &corev1.Pod{}
+		if err := r.decoder.DecodeRaw(req.OldObject, oldPod); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if err := validateGuestLabelsUpdate(oldPod, pod); err != nil {
+			metrics.RecordPodInjectionDenial(r.metricsMachineGroup(ctx, oldPod.Labels[consts.MachineGroupKey]), metrics.DenialReasonPodUpdateForbidden)
+			return admission.Denied(err.Error())
+		}
+		if r.requiredInjection(pod) {
+			keepInjectedFields(oldPod, pod)
+		}
+	} else if r.requiredInjection(pod) {
+		machineGroup := pod.Labels[consts.MachineGroupKey]
 
-		priLogger.Info(fmt.Sprintf("name: <%s>, namespace: <%s>; required injection", pod.Name, pod.Namespace))
+		// The Machine controller counts only Guest Pods in namespaces enabled injection.
+		enabled, err := r.isInjectionEnabledNamespace(ctx, req.Namespace)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !enabled {
+			if r.NamespacePolicy == NamespacePolicyDeny {
+				metrics.RecordPodInjectionDenial(r.metricsMachineGroup(ctx, machineGroup), metrics.DenialReasonNamespaceNotEnabled)
+				return admission.Denied(fmt.Sprintf("name: <%s>, namespace: <%s>; namespace does not have the label <%s=%s>",
+					pod.Name, req.Namespace, consts.ImperatorResourceInjectionKey, consts.ImperatorResourceInjectionEnabled))
+			}
+			return admission.Allowed(fmt.Sprintf("namespace <%s> is not enabled injection", req.Namespace))
+		}
 
-		if err := r.replacePods(ctx, pod); err != nil {
-			return admission.Denied(err.Error())
+		if r.CheckMachineAccess {
+			allowed, err := r.canUseMachineGroup(ctx, req.UserInfo, req.Namespace, pod, machineGroup)
+			if err != nil {
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+			if !allowed {
+				metrics.RecordPodInjectionDenial(r.metricsMachineGroup(ctx, machineGroup), metrics.DenialReasonMachineAccessDenied)
+				subject := req.UserInfo.Username
+				if isControllerUser(req.UserInfo) {
+					subject = getServiceAccountUsername(req.Namespace, pod)
+				}
+				return admission.Denied(fmt.Sprintf("name: <%s>, namespace: <%s>; <%s> can not <%s> machine-group <%s>, "+
+					"ask cluster admins to grant the verb <%s> for the resource <machines> named <%s> in the namespace",
+					pod.Name, req.Namespace, subject, consts.MachineUseVerb, machineGroup,
+					consts.MachineUseVerb, machineGroup))
+			}
 		}
-		if err := r.injectToPod(ctx, pod); err != nil {
+
+		// Inject resource to Pod
+		priLogger.Info(fmt.Sprintf("name: <%s>, namespace: <%s>; required injection", pod.Name, pod.Namespace))
+
+		if reason, err := r.injectToPod(ctx, pod); err != nil {
+			metrics.RecordPodInjectionDenial(r.metricsMachineGroup(ctx, machineGroup), reason)
 			return admission.Denied(err.Error())
 		}
+		metrics.RecordPodInjection(machineGroup, pod.Labels[consts.MachineTypeKey])
 	}
 
 	marshaledPod, err := json.Marshal(pod)
@@ -95,78 +162,197 @@ func (r *resourceInjector) requiredInjection(pod *corev1.Pod) bool {
 	return true
 }
 
-func (r *resourceInjector) replacePods(ctx context.Context, pod *corev1.Pod) error {
-
-	// fetch old Pod
-	oldPod := &corev1.Pod{}
-	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(pod), oldPod); errors.IsNotFound(err) {
-		return nil
-	}
-	return fmt.Errorf("it is forbidden to update the Pod")
-
-	// TODO: Support to replace pod
-	//if len(oldPod.OwnerReferences) == 0 && os.Getenv("SKIP_OWNER_CHECK") != "true" {
-	//	return fmt.Errorf("name: %s, namespace: %s; pods that are not managed by the parent resource can not be updated",
-	//		oldPod.Name, oldPod.Namespace)
-	//}
-	//
-	//if !oldPod.GetDeletionTimestamp().IsZero() {
-	//	return fmt.Errorf("name: %s, namespace: %s; the pod is replacing now", pod.Name, pod.Namespace)
-	//}
-	//
-	//if err := r.Client.Delete(ctx, oldPod, &client.DeleteOptions{}); err != nil {
-	//	return err
-	//}
-	//
-	//return nil
+func (r *resourceInjector) isInjectionEnabledNamespace(ctx context.Context, namespace string) (bool, error) {
+	ns := &corev1.Namespace{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return false, err
+	}
+	return ns.Labels[consts.ImperatorResourceInjectionKey] == consts.ImperatorResourceInjectionEnabled, nil
+}
+
+// canUseMachineGroup checks with SubjectAccessReview whether the requesting user can use the machine-group.
+// Pods created by controllers such as the ReplicaSet controller are requested by the controllers, so the service account of the Pod
+// is checked only for those requests. The service account is chosen by the requester, so it is not trusted for other users.
+func (r *resourceInjector) canUseMachineGroup(ctx context.Context, userInfo authenticationv1.UserInfo, namespace string, pod *corev1.Pod, machineGroup string) (bool, error) {
+	subjects := []authenticationv1.UserInfo{userInfo}
+	if isControllerUser(userInfo) {
+		subjects = append(subjects, authenticationv1.UserInfo{
+			Username: getServiceAccountUsername(namespace, pod),
+			Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:" + namespace},
+		})
+	}
+	for _, subject := range subjects {
+		extra := make(map[string]authorizationv1.ExtraValue, len(subject.Extra))
+		for k, v := range subject.Extra {
+			extra[k] = authorizationv1.ExtraValue(v)
+		}
+		sar := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      consts.MachineUseVerb,
+					Group:     GroupVersion.Group,
+					Version:   GroupVersion.Version,
+					Resource:  "machines",
+					Name:      machineGroup,
+				},
+				User:   subject.Username,
+				Groups: subject.Groups,
+				UID:    subject.UID,
+				Extra:  extra,
+			},
+		}
+		if err := r.Client.Create(ctx, sar); err != nil {
+			return false, err
+		}
+		if sar.Status.Allowed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isControllerUser returns true for kube-controller-manager and its controllers, which create Pods on behalf of users.
+func isControllerUser(userInfo authenticationv1.UserInfo) bool {
+	return userInfo.Username == "system:kube-controller-manager" || strings.HasPrefix(userInfo.Username, "system:serviceaccount:kube-system:")
+}
+
+func getServiceAccountUsername(namespace string, pod *corev1.Pod) string {
+	serviceAccountName := pod.Spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = "default"
+	}
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccountName)
+}
+
+// validateGuestLabelsUpdate rejects updates changing machine-group, machine-type or pod-role of the Pod
+// since the injected resources, affinity and toleration can not be changed after the Pod is created.
+func validateGuestLabelsUpdate(oldPod, pod *corev1.Pod) error {
+	for _, key := range []string{consts.MachineGroupKey, consts.MachineTypeKey, consts.PodRoleKey} {
+		if oldPod.Labels[key] == pod.Labels[key] {
+			continue
+		}
+		return fmt.Errorf("name: <%s>, namespace: <%s>; it is forbidden to change the label <%s> from <%s> to <%s>, please recreate the Pod",
+			pod.Name, pod.Namespace, key, oldPod.Labels[key], pod.Labels[key])
+	}
+	return nil
 }
 
-func (r *resourceInjector) injectToPod(ctx context.Context, pod *corev1.Pod) error {
-	injectingTargetContainerIdx := findInjectingTargetContainerIndex(pod)
+// keepInjectedFields copies the fields injected at creation from the old Pod.
+// Running injection again may produce different values after the Machine is changed, and those fields are immutable.
+func keepInjectedFields(oldPod, pod *corev1.Pod) {
+	// resources may be injected into multiple containers, so resources of all containers are kept.
+	for idx, c := range pod.Spec.Containers {
+		for _, oldC := range oldPod.Spec.Containers {
+			if oldC.Name != c.Name {
+				continue
+			}
+			pod.Spec.Containers[idx].Resources = *oldC.Resources.DeepCopy()
+			break
+		}
+	}
+	pod.Spec.Affinity = oldPod.Spec.Affinity.DeepCopy()
+	pod.Spec.PriorityClassName = oldPod.Spec.PriorityClassName
+	pod.Spec.Priority = oldPod.Spec.Priority
+	pod.Spec.PreemptionPolicy = oldPod.Spec.PreemptionPolicy
+
+	// tolerations can be added, so only injected tolerations are kept.
+	var injectedTolerations []corev1.Toleration
+	for _, t := range oldPod.Spec.Tolerations {
+		if strings.HasPrefix(t.Key, GroupVersion.Group+"/") {
+			injectedTolerations = append(injectedTolerations, t)
+		}
+	}
+	for _, t := range injectedTolerations {
+		if findToleration(pod.Spec.Tolerations, t.Key, t.Value) == nil {
+			pod.Spec.Tolerations = append(pod.Spec.Tolerations, t)
+		}
+	}
+}
+
+// injectToPod returns the reason recorded in metrics with error when the Pod is denied.
+func (r *resourceInjector) injectToPod(ctx context.Context, pod *corev1.Pod) (string, error) {
 	machineGroup := pod.Labels[consts.MachineGroupKey]
 	machineTypeName := pod.Labels[consts.MachineTypeKey]
 
-	targetMachineType, machineTypeUsage, err := r.findMachineType(ctx, machineGroup, machineTypeName)
+	machine, err := r.findMachine(ctx, machineGroup)
 	if err != nil {
-		return err
-	} else if targetMachineType == nil {
-		return fmt.Errorf("machine-group, <%s> does not have machine-type, <%s>", machineGroup, machineTypeName)
+		return metrics.DenialReasonMachineGroupNotFound, err
+	}
+	targetMachineType, machineTypeUsage := findMachineType(machine, machineTypeName)
+	if targetMachineType == nil {
+		return metrics.DenialReasonMachineTypeNotFound, fmt.Errorf("machine-group, <%s> does not have machine-type, <%s>", machineGroup, machineTypeName)
+	}
+
+	if machineTypeUsage == nil || machineTypeUsage.Reserved == 0 {
+		return metrics.DenialReasonNoReservedMachine, fmt.Errorf("name: <%s>, namespace: <%s>; there is no <%s> left", pod.Name, pod.Namespace, targetMachineType.Name)
+	}
+
+	if err = r.checkMachineQuota(ctx, pod, machineGroup, machineTypeName); err != nil {
+		return metrics.DenialReasonMachineQuotaExceeded, err
 	}
 
-	if machineTypeUsage.Reserved == 0 {
-		return fmt.Errorf("name: <%s>, namespace: <%s>; there is no <%s> left", pod.Name, pod.Namespace, targetMachineType.Name)
+	if err = injectMachineType(targetMachineType, machineGroup, pod); err != nil {
+		return metrics.DenialReasonInvalidResourceSplit, fmt.Errorf("name: <%s>, namespace: <%s>; %v", pod.Name, pod.Namespace, err)
 	}
 
+	// inject PriorityClass
+	if machine.Spec.ReservationMode == ReservationModePreemptible {
+		injectPriorityClass(pod)
+	}
+
+	return "", nil
+}
+
+// injectMachineType injects resources, affinity and toleration for the machineType.
+// It is also used for Pod templates of workloads, so injection must be idempotent.
+func injectMachineType(machineType *MachineType, machineGroup string, pod *corev1.Pod) error {
 	// inject resources
-	injectResource(targetMachineType, pod, injectingTargetContainerIdx)
+	if err := injectResource(machineType, pod); err != nil {
+		return err
+	}
 
 	// inject Affinity
-	requiredMatchExpressions := GenerateAffinityMatchExpression(targetMachineType, machineGroup)
+	requiredMatchExpressions := GenerateAffinityMatchExpression(machineType, machineGroup)
 	injectPodAffinity(pod, requiredMatchExpressions)
 
 	// inject Toleration
-	toleration := GenerateToleration(machineTypeName, machineGroup)
+	toleration := GenerateToleration(machineType.Name, machineGroup)
 	injectPodToleration(pod, toleration)
 
 	return nil
 }
 
-func (r *resourceInjector) findMachineType(ctx context.Context, machineGroup, machineTypeName string) (*MachineType, *UsageCondition, error) {
+// metricsMachineGroup returns machineGroup recorded in metrics.
+// machineGroup is given by labels of Pods, so machineGroups which do not exist are recorded as unknown to bound the series.
+func (r *resourceInjector) metricsMachineGroup(ctx context.Context, machineGroup string) string {
+	if machineGroup == "" {
+		return metrics.UnknownLabelValue
+	}
+	if _, err := r.findMachine(ctx, machineGroup); err != nil {
+		return metrics.UnknownLabelValue
+	}
+	return machineGroup
+}
+
+func (r *resourceInjector) findMachine(ctx context.Context, machineGroup string) (*Machine, error) {
 	machines := &MachineList{}
 	if err := r.Client.List(ctx, machines, &client.ListOptions{
 		LabelSelector: labels.SelectorFromSet(map[string]string{
 			consts.MachineGroupKey: machineGroup,
 		}),
 	}); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	if len(machines.Items) == 0 {
-		return nil, nil, fmt.Errorf("failed to find machine-group <%s>", machineGroup)
+		return nil, fmt.Errorf("failed to find machine-group <%s>", machineGroup)
 	}
+	return &machines.Items[0], nil
+}
 
-	machineTypes := machines.Items[0].Spec.MachineTypes
+func findMachineType(machine *Machine, machineTypeName string) (*MachineType, *UsageCondition) {
 	var targetMachineType *MachineType
-	for _, mt := range machineTypes {
+	for _, mt := range machine.Spec.MachineTypes {
 		if mt.Name == machineTypeName {
 			targetMachineType = &mt
 			break
@@ -174,18 +360,77 @@ func (r *resourceInjector) findMachineType(ctx context.Context, machineGroup, ma
 	}
 
 	var targetMachineStatus *UsageCondition
-	for _, mtStatus := range machines.Items[0].Status.AvailableMachines {
+	for _, mtStatus := range machine.Status.AvailableMachines {
 		if mtStatus.Name == machineTypeName {
 			targetMachineStatus = &mtStatus.Usage
 			break
 		}
 	}
 
-	return targetMachineType, targetMachineStatus, nil
+	return targetMachineType, targetMachineStatus
 }
 
+// checkMachineQuota checks whether the namespace of the Pod has room in MachineQuotas for the machineType.
+func (r *resourceInjector) checkMachineQuota(ctx context.Context, pod *corev1.Pod, machineGroup, machineTypeName string) error {
+	quotas := &MachineQuotaList{}
+	if err := r.Client.List(ctx, quotas, &client.ListOptions{
+		Namespace: pod.Namespace,
+	}); err != nil {
+		return err
+	}
+
+	var guestPods *corev1.PodList
+	for _, q := range quotas.Items {
+		if q.Spec.MachineGroup != machineGroup {
+			continue
+		}
+		for _, hard := range q.Spec.Hard {
+			if hard.Name != machineTypeName {
+				continue
+			}
+
+			if guestPods == nil {
+				guestPods = &corev1.PodList{}
+				if err := r.Client.List(ctx, guestPods, &client.ListOptions{
+					Namespace: pod.Namespace,
+					LabelSelector: labels.SelectorFromSet(map[string]string{
+						consts.MachineGroupKey: machineGroup,
+						consts.MachineTypeKey:  machineTypeName,
+						consts.PodRoleKey:      consts.PodRoleGuest,
+					}),
+				}); err != nil {
+					return err
+				}
+			}
+
+			var used int32 = 0
+			for _, p := range guestPods.Items {
+				if p.Name == pod.Name || !p.DeletionTimestamp.IsZero() ||
+					p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed {
+					continue
+				}
+				used++
+			}
+			if used >= hard.Max {
+				return fmt.Errorf("name: <%s>, namespace: <%s>; exceeded MachineQuota <%s>, <%s> is limited to %d",
+					pod.Name, pod.Namespace, q.Name, machineTypeName, hard.Max)
+			}
+		}
+	}
+
+	return nil
+}
+
+// findInjectingTargetContainerIndex finds the container by the label, or the container injected by the previous invocation.
+// The webhook is reinvoked after other webhooks add sidecars, which may be inserted before the container with index 0.
 func findInjectingTargetContainerIndex(pod *corev1.Pod) int {
-	if containerName, exist := pod.Labels[consts.ImperatorResourceInjectContainerNameKey]; exist {
+	for _, containerName := range []string{
+		pod.Labels[consts.ImperatorResourceInjectContainerNameKey],
+		pod.Annotations[consts.ImperatorInjectedContainerNameKey],
+	} {
+		if containerName == "" {
+			continue
+		}
 		for idx, c := range pod.Spec.Containers {
 			if c.Name != containerName {
 				continue
@@ -196,20 +441,32 @@ func findInjectingTargetContainerIndex(pod *corev1.Pod) int {
 	return 0
 }
 
-func injectResource(machineType *MachineType, pod *corev1.Pod, containerIdx int) {
-	resourceList := convertToResourceQuantity(machineType)
-	origin := pod.Spec.Containers[containerIdx].Resources.DeepCopy()
-
-	// inject resources
-	pod.Spec.Containers[containerIdx].Resources = corev1.ResourceRequirements{
-		Requests: resourceList,
-		Limits:   resourceList,
+func injectResource(machineType *MachineType, pod *corev1.Pod) error {
+	containerResources, err := generateContainerResources(machineType, pod)
+	if err != nil {
+		return err
+	}
+	if _, exist := pod.Annotations[consts.ImperatorContainerResourceRatioKey]; !exist {
+		metav1.SetMetaDataAnnotation(&pod.ObjectMeta, consts.ImperatorInjectedContainerNameKey,
+			pod.Spec.Containers[findInjectingTargetContainerIndex(pod)].Name)
 	}
 
-	if diff := cmp.Diff(origin, pod.Spec.Containers[containerIdx].Resources); diff != "" {
-		priLogger.Info(fmt.Sprintf("Injected resources; Name: <%s>, Namespace: <%s>", pod.Name, pod.Namespace))
-		priLogger.Info(diff)
+	for idx, c := range pod.Spec.Containers {
+		resources, exist := containerResources[c.Name]
+		if !exist {
+			continue
+		}
+		origin := c.Resources.DeepCopy()
+
+		// inject resources
+		pod.Spec.Containers[idx].Resources = resources
+
+		if diff := cmp.Diff(origin, pod.Spec.Containers[idx].Resources); diff != "" {
+			priLogger.Info(fmt.Sprintf("Injected resources; Name: <%s>, Namespace: <%s>, Container: <%s>", pod.Name, pod.Namespace, c.Name))
+			priLogger.Info(diff)
+		}
 	}
+	return nil
 }
 
 func convertToResourceQuantity(machineType *MachineType) corev1.ResourceList {
@@ -225,10 +482,10 @@ func convertToResourceQuantity(machineType *MachineType) corev1.ResourceList {
 
 func injectPodAffinity(pod *corev1.Pod, requiredMatchExpressions []corev1.NodeSelectorRequirement) {
 
-	// create key-value map to inject
-	injectedMExpressionKeys := make(map[string]string)
+	// create key-matchExpression map to inject
+	injectedMExpressions := make(map[string]corev1.NodeSelectorRequirement)
 	for _, injectedMExpression := range requiredMatchExpressions {
-		injectedMExpressionKeys[injectedMExpression.Key] = injectedMExpression.Values[0]
+		injectedMExpressions[injectedMExpression.Key] = injectedMExpression
 	}
 
 	if pod.Spec.Affinity == nil {
@@ -241,6 +498,14 @@ func injectPodAffinity(pod *corev1.Pod, requiredMatchExpressions []corev1.NodeSe
 			},
 		}
 
+	} else if pod.Spec.Affinity.NodeAffinity == nil {
+
+		pod.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{},
+			},
+		}
+
 	} else if pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
 
 		pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
@@ -251,27 +516,25 @@ func injectPodAffinity(pod *corev1.Pod, requiredMatchExpressions []corev1.NodeSe
 
 	origin := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.DeepCopy()
 
-	if len(pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) != 0 {
-
-		// remove matchExpression which has duplicated key
-		for nsIdx, nsTerm := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
-			for meIdx, mExpression := range nsTerm.MatchExpressions {
-				if injectedMExpressionKeys[mExpression.Key] != mExpression.Values[0] {
-					continue
-				}
-
-				matchExpressionsNum := len(nsTerm.MatchExpressions)
-				if meIdx == matchExpressionsNum-1 {
-					pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[nsIdx].MatchExpressions =
-						nsTerm.MatchExpressions[:matchExpressionsNum-1]
-				} else {
-					pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[nsIdx].MatchExpressions =
-						append(nsTerm.MatchExpressions[:meIdx], nsTerm.MatchExpressions[meIdx+1:]...)
-				}
+	// remove matchExpression which is the same as the injected one
+	// Terms which become empty are removed as well, since an empty term matches no Nodes.
+	var nodeSelectorTerms []corev1.NodeSelectorTerm
+	for _, nsTerm := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		var matchExpressions []corev1.NodeSelectorRequirement
+		for _, mExpression := range nsTerm.MatchExpressions {
+			if injectedMExpression, exist := injectedMExpressions[mExpression.Key]; exist &&
+				isDuplicatedMatchExpression(injectedMExpression, mExpression) {
+				continue
 			}
+			matchExpressions = append(matchExpressions, mExpression)
 		}
-
+		if len(nsTerm.MatchExpressions) != 0 && len(matchExpressions) == 0 && len(nsTerm.MatchFields) == 0 {
+			continue
+		}
+		nsTerm.MatchExpressions = matchExpressions
+		nodeSelectorTerms = append(nodeSelectorTerms, nsTerm)
 	}
+	pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = nodeSelectorTerms
 
 	pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms =
 		append(
@@ -285,6 +548,20 @@ func injectPodAffinity(pod *corev1.Pod, requiredMatchExpressions []corev1.NodeSe
 	}
 }
 
+// isDuplicatedMatchExpression returns true if mExpression has the same key, operator and values as the injected matchExpression.
+// matchExpressions set by users with the same key but other operators or values, e.g. NotIn, are kept.
+func isDuplicatedMatchExpression(injected, mExpression corev1.NodeSelectorRequirement) bool {
+	if mExpression.Key != injected.Key || mExpression.Operator != injected.Operator || len(mExpression.Values) != len(injected.Values) {
+		return false
+	}
+	for idx := range injected.Values {
+		if mExpression.Values[idx] != injected.Values[idx] {
+			return false
+		}
+	}
+	return true
+}
+
 func injectPodToleration(pod *corev1.Pod, toleration []corev1.Toleration) {
 	origin := pod.Spec.DeepCopy()
 
@@ -313,6 +590,21 @@ func injectPodToleration(pod *corev1.Pod, toleration []corev1.Toleration) {
 	}
 }
 
+// injectPriorityClass sets the PriorityClass for Guest Pods so that kube-scheduler preempts placeholder Pods.
+// The Priority admission plugin has already resolved .spec.priority before webhooks, so the value is set together.
+func injectPriorityClass(pod *corev1.Pod) {
+	if pod.Spec.PriorityClassName != "" {
+		return
+	}
+
+	preemptionPolicy := corev1.PreemptLowerPriority
+	pod.Spec.PriorityClassName = consts.GuestPriorityClassName
+	pod.Spec.Priority = pointer.Int32(consts.GuestPriority)
+	pod.Spec.PreemptionPolicy = &preemptionPolicy
+
+	priLogger.Info(fmt.Sprintf("Injected PriorityClass <%s>; Name: <%s>, Namespace: <%s>", consts.GuestPriorityClassName, pod.Name, pod.Namespace))
+}
+
 func findToleration(toleration []corev1.Toleration, tolerationKey, tolerationValue string) *int {
 	for tIdx, t := range toleration {
 		if t.Key == tolerationKey && t.Value == tolerationValue {
@@ -18,16 +18,21 @@ package v1alpha1
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
 
 	"github.com/tenzen-y/imperator/pkg/consts"
+	"github.com/tenzen-y/imperator/pkg/metrics"
 )
 
 const (
@@ -131,6 +136,7 @@ var _ = Describe("Machine Webhook", func() {
 
 	BeforeEach(func() {
 		Expect(k8sClient.DeleteAllOf(ctx, &Machine{}, &client.DeleteAllOfOptions{})).NotTo(HaveOccurred())
+		Expect(k8sClient.DeleteAllOf(ctx, &MachineQuota{}, client.InNamespace(injectedNs))).NotTo(HaveOccurred())
 		deleteAllTestGuestPods([]string{injectedNs, notInjectedNs})
 		Expect(k8sClient.DeleteAllOf(ctx, &corev1.Node{}, &client.DeleteAllOfOptions{})).NotTo(HaveOccurred())
 		fakeNodes := []string{"test-node1", "test-node2", "test-node3"}
@@ -141,7 +147,6 @@ var _ = Describe("Machine Webhook", func() {
 				return k8sClient.Get(ctx, client.ObjectKey{Name: name}, &corev1.Node{})
 			}, consts.SuiteTestTimeOut).Should(BeNil())
 		}
-		Expect(os.Setenv("SKIP_OWNER_CHECK", "true")).NotTo(HaveOccurred())
 	})
 
 	It("Inject resources, affinity, and toleration to Pod", func() {
@@ -240,20 +245,53 @@ var _ = Describe("Machine Webhook", func() {
 		Expect(k8sClient.Delete(ctx, pod, &client.DeleteOptions{})).NotTo(HaveOccurred())
 	})
 
-	//It("Pod will be updated successfully", func() {
-	//	const injectedPodName = "injected-pod"
-	//	machine := newFakeMachine()
-	//	Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
-	//
-	//	pod := newFakePod(injectedPodName, injectedNs, newTestGuestLabels(testMachineTypeName))
-	//	Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).NotTo(HaveOccurred())
-	//
-	//	getPod := &corev1.Pod{}
-	//	Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), getPod)).NotTo(HaveOccurred())
-	//	getPod.Labels[consts.MachineTypeKey] = "test-machine2"
-	//
-	//	Expect(k8sClient.Update(ctx, getPod, &client.UpdateOptions{})).NotTo(HaveOccurred())
-	//})
+	It("Pod will be updated successfully", func() {
+		const injectedPodName = "injected-pod"
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+		updateUsageConditions()
+
+		pod := newFakePod(injectedPodName, injectedNs, newTestGuestLabels(testMachineTypeName))
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		getPod := &corev1.Pod{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), getPod)).NotTo(HaveOccurred())
+		injectedResources := getPod.Spec.Containers[0].Resources
+		injectedAffinity := getPod.Spec.Affinity
+
+		// Machine is changed after the Pod is created.
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), machine)).NotTo(HaveOccurred())
+		machine.Spec.MachineTypes[0].Spec.CPU = resource.MustParse("4")
+		Expect(k8sClient.Update(ctx, machine, &client.UpdateOptions{})).NotTo(HaveOccurred())
+
+		getPod.Labels["example.com/foo"] = "bar"
+		getPod.Annotations = map[string]string{"example.com/foo": "bar"}
+		Expect(k8sClient.Update(ctx, getPod, &client.UpdateOptions{})).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), getPod)).NotTo(HaveOccurred())
+		Expect(getPod.Labels).To(HaveKeyWithValue("example.com/foo", "bar"))
+		Expect(getPod.Spec.Containers[0].Resources).To(Equal(injectedResources))
+		Expect(getPod.Spec.Affinity).To(Equal(injectedAffinity))
+	})
+
+	It("Changing machine-type of Pod is denied", func() {
+		const injectedPodName = "injected-pod"
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+		updateUsageConditions()
+
+		pod := newFakePod(injectedPodName, injectedNs, newTestGuestLabels(testMachineTypeName))
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		getPod := &corev1.Pod{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), getPod)).NotTo(HaveOccurred())
+		getPod.Labels[consts.MachineTypeKey] = "test-machine2"
+
+		err := k8sClient.Update(ctx, getPod, &client.UpdateOptions{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(consts.MachineTypeKey))
+	})
+
 	It("Specify a container name to inject resources using `imperator.tenzen-y.io/injecting-container` label", func() {
 		const injectedPodName = "injected-pod"
 		machine := newFakeMachine()
@@ -335,6 +373,205 @@ var _ = Describe("Machine Webhook", func() {
 		Expect(k8sClient.Update(ctx, getPod, &client.UpdateOptions{})).ShouldNot(BeNil())
 	})
 
+	It("Inject PriorityClass to Pod in Preemptible reservation mode", func() {
+		machine := newFakeMachine()
+		machine.Spec.ReservationMode = ReservationModePreemptible
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+		updateUsageConditions()
+
+		pod := newFakePod("preemptible-pod", injectedNs, newTestGuestLabels(testMachineTypeName))
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).NotTo(HaveOccurred())
+		Eventually(func() string {
+			getPod := &corev1.Pod{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), getPod)).NotTo(HaveOccurred())
+			return getPod.Spec.PriorityClassName
+		}, consts.SuiteTestTimeOut).Should(Equal(consts.GuestPriorityClassName))
+	})
+
+	It("Denial of Guest Pod with machine-group which does not exist is recorded as unknown", func() {
+		const notExistMachineGroup = "not-exist-machine-group"
+		unknownDenials := func() float64 {
+			return testutil.ToFloat64(metrics.PodInjectionDenials.WithLabelValues(metrics.UnknownLabelValue, metrics.DenialReasonMachineGroupNotFound))
+		}
+		before := unknownDenials()
+
+		podLabels := newTestGuestLabels(testMachineTypeName)
+		podLabels[consts.MachineGroupKey] = notExistMachineGroup
+		pod := newFakePod("not-exist-machine-group-pod", injectedNs, podLabels)
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).To(HaveOccurred())
+
+		Expect(unknownDenials()).To(Equal(before + 1))
+		Expect(testutil.ToFloat64(metrics.PodInjectionDenials.WithLabelValues(notExistMachineGroup, metrics.DenialReasonMachineGroupNotFound))).To(BeZero())
+	})
+
+	It("Guest Pod in namespace not enabled injection is denied", func() {
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+		updateUsageConditions()
+
+		pod := newFakePod("not-enabled-ns-pod", notInjectedNs, newTestGuestLabels(testMachineTypeName))
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).To(HaveOccurred())
+
+		// Pods not using machineTypes are not affected.
+		pod = newFakePod("not-guest-pod", notInjectedNs, nil)
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).NotTo(HaveOccurred())
+		Expect(k8sClient.Delete(ctx, pod, &client.DeleteOptions{})).NotTo(HaveOccurred())
+	})
+
+	It("Guest Pod created by users who can not use machine-group is denied", func() {
+		const testUserName = "imperator-test-user"
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+		updateUsageConditions()
+
+		user, err := testEnv.AddUser(envtest.User{Name: testUserName}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		userClient, err := client.New(user.Config(), client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).NotTo(HaveOccurred())
+
+		rbacResources := []client.Object{
+			&rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-creator", Namespace: injectedNs},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""},
+					Resources: []string{"pods"},
+					Verbs:     []string{"create"},
+				}},
+			},
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-creator", Namespace: injectedNs},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: testUserName}},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "pod-creator"},
+			},
+		}
+		for _, o := range rbacResources {
+			Expect(k8sClient.Create(ctx, o, &client.CreateOptions{})).NotTo(HaveOccurred())
+		}
+
+		pod := newFakePod("access-check-pod", injectedNs, newTestGuestLabels(testMachineTypeName))
+		Expect(userClient.Create(ctx, pod.DeepCopy(), &client.CreateOptions{})).To(HaveOccurred())
+
+		// grant the verb, use for the machine-group
+		useResources := []client.Object{
+			&rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine-user", Namespace: injectedNs},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups:     []string{GroupVersion.Group},
+					Resources:     []string{"machines"},
+					ResourceNames: []string{testMachineGroup},
+					Verbs:         []string{consts.MachineUseVerb},
+				}},
+			},
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine-user", Namespace: injectedNs},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: testUserName}},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "machine-user"},
+			},
+		}
+		for _, o := range useResources {
+			Expect(k8sClient.Create(ctx, o, &client.CreateOptions{})).NotTo(HaveOccurred())
+		}
+		Eventually(func() error {
+			return userClient.Create(ctx, pod.DeepCopy(), &client.CreateOptions{})
+		}, consts.SuiteTestTimeOut).Should(BeNil())
+
+		for _, o := range append(rbacResources, useResources...) {
+			Expect(k8sClient.Delete(ctx, o, &client.DeleteOptions{})).NotTo(HaveOccurred())
+		}
+	})
+
+	It("Guest Pod created by users who can not use machine-group is denied even with a privileged service account", func() {
+		const (
+			testUserName = "imperator-test-unprivileged-user"
+			testSAName   = "machine-user-sa"
+		)
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+		updateUsageConditions()
+
+		user, err := testEnv.AddUser(envtest.User{Name: testUserName}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		userClient, err := client.New(user.Config(), client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).NotTo(HaveOccurred())
+
+		// the user can create Pods, and the service account can use the machine-group
+		resources := []client.Object{
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: testSAName, Namespace: injectedNs},
+			},
+			&rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-creator", Namespace: injectedNs},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""},
+					Resources: []string{"pods"},
+					Verbs:     []string{"create"},
+				}},
+			},
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-creator", Namespace: injectedNs},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: testUserName}},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "pod-creator"},
+			},
+			&rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine-user", Namespace: injectedNs},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups:     []string{GroupVersion.Group},
+					Resources:     []string{"machines"},
+					ResourceNames: []string{testMachineGroup},
+					Verbs:         []string{consts.MachineUseVerb},
+				}},
+			},
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "machine-user", Namespace: injectedNs},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: testSAName, Namespace: injectedNs}},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "machine-user"},
+			},
+		}
+		for _, o := range resources {
+			Expect(k8sClient.Create(ctx, o, &client.CreateOptions{})).NotTo(HaveOccurred())
+		}
+
+		pod := newFakePod("privileged-sa-pod", injectedNs, newTestGuestLabels(testMachineTypeName))
+		pod.Spec.ServiceAccountName = testSAName
+		err = userClient.Create(ctx, pod, &client.CreateOptions{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(testUserName))
+
+		for _, o := range resources {
+			Expect(k8sClient.Delete(ctx, o, &client.DeleteOptions{})).NotTo(HaveOccurred())
+		}
+	})
+
+	It("Pod exceeding MachineQuota is denied", func() {
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+		updateUsageConditions()
+
+		quota := &MachineQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-quota",
+				Namespace: injectedNs,
+			},
+			Spec: MachineQuotaSpec{
+				MachineGroup: testMachineGroup,
+				Hard: []MachineTypeQuota{{
+					Name: testMachineTypeName,
+					Max:  1,
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, quota, &client.CreateOptions{})).NotTo(HaveOccurred())
+		Eventually(func() error {
+			return k8sClient.Get(ctx, client.ObjectKeyFromObject(quota), &MachineQuota{})
+		}, consts.SuiteTestTimeOut).Should(BeNil())
+
+		pod := newFakePod("quota-pod1", injectedNs, newTestGuestLabels(testMachineTypeName))
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).NotTo(HaveOccurred())
+		Eventually(func() error {
+			return k8sClient.Create(ctx, newFakePod("quota-pod2", injectedNs, newTestGuestLabels(testMachineTypeName)), &client.CreateOptions{})
+		}, consts.SuiteTestTimeOut).Should(HaveOccurred())
+	})
+
 	It("Create Pod", func() {
 		testCases := []struct {
 			description string
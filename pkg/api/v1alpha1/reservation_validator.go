@@ -0,0 +1,201 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+var rvLogger = ctrl.Log.WithName("reservation-validator")
+
+// +kubebuilder:webhook:path=/validate-reservation,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,groups="";apps,resources=pods;services;statefulsets,verbs=create;update;delete,versions=v1,name=validator.reservation.imperator.tenzen-y.io,admissionReviewVersions={v1,v1beta1}
+// Scale objects do not have labels of the StatefulSet, so the scale subresource is validated by another webhook without objectSelector.
+// +kubebuilder:webhook:path=/validate-reservation,matchPolicy=equivalent,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps,resources=statefulsets/scale,verbs=update,versions=v1,name=validator.reservation-scale.imperator.tenzen-y.io,admissionReviewVersions={v1,v1beta1}
+
+func NewReservationValidator(c client.Client) *reservationValidator {
+	return &reservationValidator{
+		Client: c,
+	}
+}
+
+// reservationValidator rejects reservation Pods, StatefulSets and Services created or edited by others than imperator,
+// since the Machine controller counts reservation Pods as reserved machines.
+type reservationValidator struct {
+	Client client.Client
+}
+
+func (r *reservationValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.SubResource == "scale" {
+		return r.handleScale(ctx, req)
+	}
+
+	// Object is empty in DELETE, so the deleted object is validated.
+	raw := req.Object.Raw
+	if req.Operation == admissionv1.Delete {
+		raw = req.OldObject.Raw
+	}
+	obj := &metav1.PartialObjectMetadata{}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	var oldObj *metav1.PartialObjectMetadata
+	if req.Operation == admissionv1.Update {
+		oldObj = &metav1.PartialObjectMetadata{}
+		if err := json.Unmarshal(req.OldObject.Raw, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	if !isReservationObject(obj) && (oldObj == nil || !isReservationObject(oldObj)) {
+		return admission.Allowed("")
+	}
+	if isPrivilegedUser(req.UserInfo) {
+		return admission.Allowed("")
+	}
+
+	switch req.Kind.Kind {
+	case "Pod":
+		// Only reservation Pods in the core namespace are counted by the Machine controller.
+		if req.Operation == admissionv1.Delete && req.Namespace != consts.ImperatorCoreNamespace {
+			return admission.Allowed("")
+		}
+		// Edits except for labels used to count reservation Pods are allowed.
+		if oldObj != nil && !reservationLabelsChanged(oldObj, obj) {
+			return admission.Allowed("")
+		}
+		if req.Operation == admissionv1.Create {
+			owned, err := r.isOwnedByMachine(ctx, req.Namespace, obj)
+			if err != nil {
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+			if owned {
+				return admission.Allowed("")
+			}
+		}
+	case "StatefulSet", "Service":
+		// Only the reservation resources in the core namespace are used by the Machine controller.
+		if req.Namespace != consts.ImperatorCoreNamespace {
+			return admission.Allowed("")
+		}
+	default:
+		return admission.Allowed(fmt.Sprintf("kind <%s> is not supported", req.Kind.Kind))
+	}
+
+	return denyReservation(req)
+}
+
+// handleScale rejects scaling reservation StatefulSets in the core namespace.
+func (r *reservationValidator) handleScale(ctx context.Context, req admission.Request) admission.Response {
+	if req.Namespace != consts.ImperatorCoreNamespace || isPrivilegedUser(req.UserInfo) {
+		return admission.Allowed("")
+	}
+	sts := &appsv1.StatefulSet{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: req.Name}, sts); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Allowed("")
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if !isReservationObject(&metav1.PartialObjectMetadata{ObjectMeta: sts.ObjectMeta}) {
+		return admission.Allowed("")
+	}
+	return denyReservation(req)
+}
+
+func denyReservation(req admission.Request) admission.Response {
+	rvLogger.Info(fmt.Sprintf("denied; kind: <%s>, name: <%s>, namespace: <%s>, user: <%s>",
+		req.Kind.Kind, req.Name, req.Namespace, req.UserInfo.Username))
+	return admission.Denied(fmt.Sprintf("%s <%s> with the label <%s=%s> can be created, edited or deleted only by imperator",
+		req.Kind.Kind, req.Name, consts.PodRoleKey, consts.PodRoleReservation))
+}
+
+func isReservationObject(obj *metav1.PartialObjectMetadata) bool {
+	return obj.Labels[consts.PodRoleKey] == consts.PodRoleReservation
+}
+
+func reservationLabelsChanged(oldObj, obj *metav1.PartialObjectMetadata) bool {
+	for _, key := range []string{consts.MachineGroupKey, consts.MachineTypeKey, consts.PodRoleKey} {
+		if oldObj.Labels[key] != obj.Labels[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// isPrivilegedUser returns true for the imperator controller and controllers of kube-controller-manager
+// such as the garbage collector and the StatefulSet controller.
+func isPrivilegedUser(userInfo authenticationv1.UserInfo) bool {
+	if userInfo.Username == consts.ImperatorServiceAccountUsername() || userInfo.Username == "system:kube-controller-manager" {
+		return true
+	}
+	for _, g := range userInfo.Groups {
+		if g == "system:serviceaccounts:kube-system" {
+			return true
+		}
+	}
+	return false
+}
+
+// isOwnedByMachine checks whether the Pod is controlled by a reservation StatefulSet which is controlled by Machine.
+func (r *reservationValidator) isOwnedByMachine(ctx context.Context, namespace string, pod *metav1.PartialObjectMetadata) (bool, error) {
+	if namespace != consts.ImperatorCoreNamespace {
+		return false, nil
+	}
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil || ref.Kind != "StatefulSet" {
+		return false, nil
+	}
+
+	sts := &appsv1.StatefulSet{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, sts); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if sts.UID != ref.UID {
+		return false, nil
+	}
+	stsRef := metav1.GetControllerOf(sts)
+	if stsRef == nil || stsRef.Kind != consts.KindMachine {
+		return false, nil
+	}
+	if gv, err := schema.ParseGroupVersion(stsRef.APIVersion); err != nil || gv.Group != GroupVersion.Group {
+		return false, nil
+	}
+	// ownerReferences can be set by users, so the Machine must exist and have the UID.
+	machine := &Machine{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: stsRef.Name}, machine); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if machine.UID != stsRef.UID || machine.Labels[consts.MachineGroupKey] != sts.Labels[consts.MachineGroupKey] {
+		return false, nil
+	}
+	return !reservationLabelsChanged(&metav1.PartialObjectMetadata{ObjectMeta: sts.ObjectMeta}, pod), nil
+}
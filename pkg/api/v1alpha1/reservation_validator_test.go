@@ -0,0 +1,170 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+func newTestReservationLabels(machineTypeName string) map[string]string {
+	return map[string]string{
+		consts.MachineGroupKey: testMachineGroup,
+		consts.MachineTypeKey:  machineTypeName,
+		consts.PodRoleKey:      consts.PodRoleReservation,
+	}
+}
+
+func newFakeReservationStatefulSet(namespace string, stsLabels map[string]string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-reservation",
+			Namespace: namespace,
+			Labels:    stsLabels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: stsLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: stsLabels},
+				Spec:       newFakePod("", "", stsLabels).Spec,
+			},
+		},
+	}
+}
+
+// newImperatorClient returns the client authenticated as the imperator's service account.
+func newImperatorClient() client.Client {
+	user, err := testEnv.AddUser(envtest.User{
+		Name:   consts.ImperatorServiceAccountUsername(),
+		Groups: []string{"system:masters"},
+	}, nil)
+	Expect(err).NotTo(HaveOccurred())
+	imperatorClient, err := client.New(user.Config(), client.Options{Scheme: k8sClient.Scheme()})
+	Expect(err).NotTo(HaveOccurred())
+	return imperatorClient
+}
+
+var _ = Describe("Reservation Validator", func() {
+	const testMachineTypeName = "test-machine1"
+
+	var imperatorClient client.Client
+
+	BeforeEach(func() {
+		coreNs := &corev1.Namespace{}
+		coreNs.Name = consts.ImperatorCoreNamespace
+		if err := k8sClient.Create(ctx, coreNs, &client.CreateOptions{}); err != nil {
+			Expect(apierrors.IsAlreadyExists(err)).To(BeTrue())
+		}
+		imperatorClient = newImperatorClient()
+		for _, ns := range []string{injectedNs, consts.ImperatorCoreNamespace} {
+			Expect(imperatorClient.DeleteAllOf(ctx, &appsv1.StatefulSet{}, client.InNamespace(ns))).NotTo(HaveOccurred())
+		}
+		Expect(imperatorClient.DeleteAllOf(ctx, &corev1.Pod{}, client.InNamespace(consts.ImperatorCoreNamespace))).NotTo(HaveOccurred())
+		Expect(k8sClient.DeleteAllOf(ctx, &Machine{}, &client.DeleteAllOfOptions{})).NotTo(HaveOccurred())
+	})
+
+	It("Reservation Pod created by users is denied", func() {
+		pod := newFakePod("fake-reservation-pod", injectedNs, newTestReservationLabels(testMachineTypeName))
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).To(HaveOccurred())
+
+		pod.Namespace = consts.ImperatorCoreNamespace
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).To(HaveOccurred())
+	})
+
+	It("Changing Pod to reservation Pod is denied", func() {
+		pod := newFakePod("relabeled-pod", injectedNs, map[string]string{"app": "test"})
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		getPod := &corev1.Pod{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), getPod)).NotTo(HaveOccurred())
+		getPod.Labels = newTestReservationLabels(testMachineTypeName)
+		Expect(k8sClient.Update(ctx, getPod, &client.UpdateOptions{})).To(HaveOccurred())
+
+		Expect(k8sClient.Delete(ctx, pod, &client.DeleteOptions{})).NotTo(HaveOccurred())
+	})
+
+	It("Reservation StatefulSet in the core namespace created by users is denied", func() {
+		sts := newFakeReservationStatefulSet(consts.ImperatorCoreNamespace, newTestReservationLabels(testMachineTypeName))
+		Expect(k8sClient.Create(ctx, sts, &client.CreateOptions{})).To(HaveOccurred())
+
+		// StatefulSets in other namespaces are not used by the Machine controller.
+		sts = newFakeReservationStatefulSet(injectedNs, newTestReservationLabels(testMachineTypeName))
+		Expect(k8sClient.Create(ctx, sts, &client.CreateOptions{})).NotTo(HaveOccurred())
+	})
+
+	It("Deleting and scaling reservation StatefulSet in the core namespace by users is denied", func() {
+		sts := newFakeReservationStatefulSet(consts.ImperatorCoreNamespace, newTestReservationLabels(testMachineTypeName))
+		Expect(imperatorClient.Create(ctx, sts, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		clientset, err := kubernetes.NewForConfig(testEnv.Config)
+		Expect(err).NotTo(HaveOccurred())
+		scale, err := clientset.AppsV1().StatefulSets(sts.Namespace).GetScale(ctx, sts.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		scale.Spec.Replicas = 5
+		_, err = clientset.AppsV1().StatefulSets(sts.Namespace).UpdateScale(ctx, sts.Name, scale, metav1.UpdateOptions{})
+		Expect(err).To(HaveOccurred())
+
+		Expect(k8sClient.Delete(ctx, sts, &client.DeleteOptions{})).To(HaveOccurred())
+		Expect(imperatorClient.Delete(ctx, sts, &client.DeleteOptions{})).NotTo(HaveOccurred())
+	})
+
+	It("Reservation Pod controlled by StatefulSet with forged ownerReference of Machine is denied", func() {
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		newReservationPod := func(sts *appsv1.StatefulSet) *corev1.Pod {
+			pod := newFakePod(sts.Name+"-0", consts.ImperatorCoreNamespace, newTestReservationLabels(testMachineTypeName))
+			pod.OwnerReferences = []metav1.OwnerReference{
+				*metav1.NewControllerRef(sts, appsv1.SchemeGroupVersion.WithKind("StatefulSet")),
+			}
+			return pod
+		}
+
+		// The UID in the ownerReference is not the UID of the Machine.
+		forged := newFakeReservationStatefulSet(consts.ImperatorCoreNamespace, newTestReservationLabels(testMachineTypeName))
+		forgedMachine := machine.DeepCopy()
+		forgedMachine.UID = types.UID("forged-uid")
+		forged.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(forgedMachine, GroupVersion.WithKind(consts.KindMachine))}
+		Expect(imperatorClient.Create(ctx, forged, &client.CreateOptions{})).NotTo(HaveOccurred())
+		Expect(k8sClient.Create(ctx, newReservationPod(forged), &client.CreateOptions{})).To(HaveOccurred())
+		Expect(imperatorClient.Delete(ctx, forged, &client.DeleteOptions{})).NotTo(HaveOccurred())
+
+		getMachine := &Machine{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), getMachine)).NotTo(HaveOccurred())
+		sts := newFakeReservationStatefulSet(consts.ImperatorCoreNamespace, newTestReservationLabels(testMachineTypeName))
+		sts.Name = "owned-reservation"
+		sts.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(getMachine, GroupVersion.WithKind(consts.KindMachine))}
+		Expect(imperatorClient.Create(ctx, sts, &client.CreateOptions{})).NotTo(HaveOccurred())
+		pod := newReservationPod(sts)
+		Eventually(func() error {
+			return k8sClient.Create(ctx, pod.DeepCopy(), &client.CreateOptions{})
+		}, consts.SuiteTestTimeOut).Should(BeNil())
+
+		// Deleting reservation Pods in the core namespace by users is denied.
+		Expect(k8sClient.Delete(ctx, pod, &client.DeleteOptions{})).To(HaveOccurred())
+	})
+})
@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"github.com/tenzen-y/imperator/pkg/api/v1beta1"
 	"github.com/tenzen-y/imperator/pkg/consts"
 )
 
@@ -64,12 +65,28 @@ var _ = BeforeSuite(func() {
 
 	ctx, cancel = context.WithCancel(context.TODO())
 
+	scheme := runtime.NewScheme()
+	err := AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = v1beta1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = clientgoscheme.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	// +kubebuilder:scaffold:scheme
+
 	By("bootstrapping test environment")
 	testEnv = &envtest.Environment{
+		Scheme:                scheme,
 		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "..", "config", "crd", "bases")},
 		ErrorIfCRDPathMissing: false,
 		WebhookInstallOptions: envtest.WebhookInstallOptions{
-			Paths: []string{filepath.Join("..", "..", "..", "config", "webhook")},
+			Paths: []string{
+				filepath.Join("..", "..", "..", "config", "webhook"),
+				filepath.Join("..", "..", "..", "config", "workload-injector", "webhook.yaml"),
+			},
 		},
 	}
 
@@ -77,15 +94,6 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	Expect(cfg).NotTo(BeNil())
 
-	scheme := runtime.NewScheme()
-	err = AddToScheme(scheme)
-	Expect(err).NotTo(HaveOccurred())
-
-	err = clientgoscheme.AddToScheme(scheme)
-	Expect(err).NotTo(HaveOccurred())
-
-	// +kubebuilder:scaffold:scheme
-
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme})
 	Expect(err).NotTo(HaveOccurred())
 	Expect(k8sClient).NotTo(BeNil())
@@ -104,9 +112,21 @@ var _ = BeforeSuite(func() {
 
 	err = (&Machine{}).SetupWebhookWithManager(ctx, mgr)
 	Expect(err).NotTo(HaveOccurred())
+	err = (&v1beta1.Machine{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+	err = (&v1beta1.MachineNodePool{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+	err = (&v1beta1.MachineQuota{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
 
 	mgr.GetWebhookServer().Register(consts.PodResourceInjectorPath, &webhook.Admission{
-		Handler: NewResourceInjector(k8sClient),
+		Handler: NewResourceInjector(k8sClient, NamespacePolicyDeny, true),
+	})
+	mgr.GetWebhookServer().Register(consts.WorkloadResourceInjectorPath, &webhook.Admission{
+		Handler: NewWorkloadResourceInjector(k8sClient, NamespacePolicyDeny, true),
+	})
+	mgr.GetWebhookServer().Register(consts.ReservationValidatorPath, &webhook.Admission{
+		Handler: NewReservationValidator(k8sClient),
 	})
 
 	// +kubebuilder:scaffold:webhook
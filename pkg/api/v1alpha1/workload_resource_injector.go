@@ -0,0 +1,184 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+var wriLogger = ctrl.Log.WithName("workload-resource-injector")
+
+// The webhook for the workload resource injector is not generated from markers,
+// since it is installed only with --enable-workload-injector by config/workload-injector.
+
+func NewWorkloadResourceInjector(c client.Client, namespacePolicy NamespacePolicy, checkMachineAccess bool) *workloadResourceInjector {
+	return &workloadResourceInjector{
+		resourceInjector: resourceInjector{
+			Client:             c,
+			NamespacePolicy:    namespacePolicy,
+			CheckMachineAccess: checkMachineAccess,
+		},
+	}
+}
+
+// workloadResourceInjector injects resources, affinity and toleration into Pod templates of workloads,
+// so that users can see the actual requests in workloads and invalid labels are rejected before Pods are created.
+// Guest Pods created from the templates are still handled by resourceInjector.
+type workloadResourceInjector struct {
+	resourceInjector
+}
+
+// newWorkload returns an empty workload of the kind and its Pod template.
+func newWorkload(kind string) (runtime.Object, *corev1.PodTemplateSpec) {
+	switch kind {
+	case "Deployment":
+		deploy := &appsv1.Deployment{}
+		return deploy, &deploy.Spec.Template
+	case "StatefulSet":
+		sts := &appsv1.StatefulSet{}
+		return sts, &sts.Spec.Template
+	case "Job":
+		job := &batchv1.Job{}
+		return job, &job.Spec.Template
+	case "CronJob":
+		cronJob := &batchv1.CronJob{}
+		return cronJob, &cronJob.Spec.JobTemplate.Spec.Template
+	}
+	return nil, nil
+}
+
+func (r *workloadResourceInjector) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj, template := newWorkload(req.Kind.Kind)
+	if obj == nil {
+		return admission.Allowed(fmt.Sprintf("kind <%s> is not supported", req.Kind.Kind))
+	}
+	if err := r.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// Pod is used to share the injection logic with resourceInjector.
+	pod := &corev1.Pod{
+		ObjectMeta: template.ObjectMeta,
+		Spec:       template.Spec,
+	}
+	pod.Name, pod.Namespace = req.Name, req.Namespace
+	if r.requiredInjection(pod) {
+		enabled, err := r.isInjectionEnabledNamespace(ctx, req.Namespace)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !enabled {
+			if req.Operation == admissionv1.Create && r.NamespacePolicy == NamespacePolicyDeny {
+				return admission.Denied(fmt.Sprintf("namespace <%s> does not have the label <%s=%s>",
+					req.Namespace, consts.ImperatorResourceInjectionKey, consts.ImperatorResourceInjectionEnabled))
+			}
+			return admission.Allowed(fmt.Sprintf("namespace <%s> is not enabled injection", req.Namespace))
+		}
+
+		// Guest Pods created by controllers are checked with the service account in the template,
+		// so the user creating the workload or changing its machine-group must be able to use the machine-group.
+		if r.CheckMachineAccess {
+			changed, err := r.machineGroupChanged(req, template)
+			if err != nil {
+				return admission.Errored(http.StatusBadRequest, err)
+			}
+			if changed {
+				machineGroup := pod.Labels[consts.MachineGroupKey]
+				allowed, err := r.canUseMachineGroup(ctx, req.UserInfo, req.Namespace, pod, machineGroup)
+				if err != nil {
+					return admission.Errored(http.StatusInternalServerError, err)
+				}
+				if !allowed {
+					return admission.Denied(fmt.Sprintf("name: <%s>, namespace: <%s>; <%s> can not <%s> machine-group <%s>, "+
+						"ask cluster admins to grant the verb <%s> for the resource <machines> named <%s> in the namespace",
+						req.Name, req.Namespace, req.UserInfo.Username, consts.MachineUseVerb, machineGroup,
+						consts.MachineUseVerb, machineGroup))
+				}
+			}
+		}
+
+		if err = r.injectToTemplate(ctx, pod); err != nil {
+			// Existing workloads are not blocked since Guest Pods are validated by resourceInjector.
+			if req.Operation == admissionv1.Create {
+				return admission.Denied(err.Error())
+			}
+			wriLogger.Info(fmt.Sprintf("skipped to inject; %v", err))
+			return admission.Allowed("")
+		}
+		template.Spec = pod.Spec
+	}
+
+	marshaledObj, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledObj)
+}
+
+// machineGroupChanged returns true if the workload is created or machine-group of its Pod template is changed.
+func (r *workloadResourceInjector) machineGroupChanged(req admission.Request, template *corev1.PodTemplateSpec) (bool, error) {
+	if req.Operation != admissionv1.Update {
+		return true, nil
+	}
+	oldObj, oldTemplate := newWorkload(req.Kind.Kind)
+	if err := r.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+		return false, err
+	}
+	return oldTemplate.Labels[consts.MachineGroupKey] != template.Labels[consts.MachineGroupKey], nil
+}
+
+// injectToTemplate injects fields for machineType to the Pod made from a Pod template.
+// The number of reserved machines and MachineQuota are not checked since those are checked when Pods are created.
+func (r *workloadResourceInjector) injectToTemplate(ctx context.Context, pod *corev1.Pod) error {
+	machineGroup := pod.Labels[consts.MachineGroupKey]
+	machineTypeName := pod.Labels[consts.MachineTypeKey]
+
+	machine, err := r.findMachine(ctx, machineGroup)
+	if err != nil {
+		return err
+	}
+	targetMachineType, _ := findMachineType(machine, machineTypeName)
+	if targetMachineType == nil {
+		return fmt.Errorf("machine-group, <%s> does not have machine-type, <%s>", machineGroup, machineTypeName)
+	}
+
+	if err = injectMachineType(targetMachineType, machineGroup, pod); err != nil {
+		return err
+	}
+
+	// Only the PriorityClass name is set since .spec.priority of Pods is resolved by the Priority admission plugin.
+	if machine.Spec.ReservationMode == ReservationModePreemptible && pod.Spec.PriorityClassName == "" {
+		pod.Spec.PriorityClassName = consts.GuestPriorityClassName
+	}
+
+	wriLogger.Info(fmt.Sprintf("Injected to Pod template; Name: <%s>, Namespace: <%s>", pod.Name, pod.Namespace))
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+func newFakePodTemplate(podLabels map[string]string) corev1.PodTemplateSpec {
+	pod := newFakePod("", "", podLabels)
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+		Spec:       pod.Spec,
+	}
+}
+
+func newFakeDeployment(name string, podLabels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: injectedNs,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+			Template: newFakePodTemplate(podLabels),
+		},
+	}
+}
+
+var _ = Describe("Workload Resource Injector", func() {
+	const testMachineTypeName = "test-machine1"
+
+	BeforeEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &Machine{}, &client.DeleteAllOfOptions{})).NotTo(HaveOccurred())
+		Expect(k8sClient.DeleteAllOf(ctx, &appsv1.Deployment{}, client.InNamespace(injectedNs))).NotTo(HaveOccurred())
+		Expect(k8sClient.DeleteAllOf(ctx, &batchv1.Job{}, client.InNamespace(injectedNs))).NotTo(HaveOccurred())
+	})
+
+	It("Inject resources, affinity, and toleration to Deployment template", func() {
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		deploy := newFakeDeployment("test-deploy", newTestGuestLabels(testMachineTypeName))
+		Expect(k8sClient.Create(ctx, deploy, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		getDeploy := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(deploy), getDeploy)).NotTo(HaveOccurred())
+		resource := convertToResourceQuantity(&machine.Spec.MachineTypes[0])
+		Expect(getDeploy.Spec.Template.Spec.Containers[0].Resources).To(Equal(corev1.ResourceRequirements{
+			Requests: resource,
+			Limits:   resource,
+		}))
+		Expect(getDeploy.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(
+			ContainElement(corev1.NodeSelectorTerm{
+				MatchExpressions: GenerateAffinityMatchExpression(&machine.Spec.MachineTypes[0], testMachineGroup),
+			}))
+		Expect(getDeploy.Spec.Template.Spec.Tolerations).To(ContainElements(GenerateToleration(testMachineTypeName, testMachineGroup)))
+
+		// injection is idempotent, so updates do not change the template.
+		injectedTemplate := getDeploy.Spec.Template.DeepCopy()
+		getDeploy.Annotations = map[string]string{"example.com/foo": "bar"}
+		Expect(k8sClient.Update(ctx, getDeploy, &client.UpdateOptions{})).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(deploy), getDeploy)).NotTo(HaveOccurred())
+		Expect(getDeploy.Spec.Template).To(Equal(*injectedTemplate))
+	})
+
+	It("Inject resources to Job template", func() {
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-job",
+				Namespace: injectedNs,
+			},
+			Spec: batchv1.JobSpec{
+				Template: newFakePodTemplate(newTestGuestLabels(testMachineTypeName)),
+			},
+		}
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+		Expect(k8sClient.Create(ctx, job, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		getJob := &batchv1.Job{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(job), getJob)).NotTo(HaveOccurred())
+		resource := convertToResourceQuantity(&machine.Spec.MachineTypes[0])
+		Expect(getJob.Spec.Template.Spec.Containers[0].Resources.Requests).To(Equal(resource))
+	})
+
+	It("Deployment with machine-type which does not exist is denied", func() {
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		deploy := newFakeDeployment("test-deploy", newTestGuestLabels("not-exist-machine"))
+		Expect(k8sClient.Create(ctx, deploy, &client.CreateOptions{})).To(HaveOccurred())
+
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(deploy), &appsv1.Deployment{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Deployment created by users who can not use machine-group is denied", func() {
+		const testUserName = "imperator-test-workload-user"
+		machine := newFakeMachine()
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		user, err := testEnv.AddUser(envtest.User{Name: testUserName}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		userClient, err := client.New(user.Config(), client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).NotTo(HaveOccurred())
+
+		rbacResources := []client.Object{
+			&rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "deployment-creator", Namespace: injectedNs},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{appsv1.GroupName},
+					Resources: []string{"deployments"},
+					Verbs:     []string{"create"},
+				}},
+			},
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "deployment-creator", Namespace: injectedNs},
+				Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: testUserName}},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "deployment-creator"},
+			},
+		}
+		for _, o := range rbacResources {
+			Expect(k8sClient.Create(ctx, o, &client.CreateOptions{})).NotTo(HaveOccurred())
+		}
+
+		deploy := newFakeDeployment("access-check-deploy", newTestGuestLabels(testMachineTypeName))
+		Eventually(func() error {
+			return userClient.Create(ctx, deploy.DeepCopy(), &client.CreateOptions{})
+		}, consts.SuiteTestTimeOut).Should(HaveOccurred())
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(deploy), &appsv1.Deployment{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		for _, o := range rbacResources {
+			Expect(k8sClient.Delete(ctx, o, &client.DeleteOptions{})).NotTo(HaveOccurred())
+		}
+	})
+
+	It("Deployment without guest labels is not injected", func() {
+		podLabels := map[string]string{consts.MachineGroupKey: testMachineGroup}
+		deploy := newFakeDeployment("test-deploy", podLabels)
+		Expect(k8sClient.Create(ctx, deploy, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		getDeploy := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(deploy), getDeploy)).NotTo(HaveOccurred())
+		Expect(getDeploy.Spec.Template.Spec.Affinity).To(BeNil())
+	})
+})
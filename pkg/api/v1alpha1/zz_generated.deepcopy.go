@@ -22,7 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -30,6 +31,11 @@ import (
 func (in *AvailableMachineCondition) DeepCopyInto(out *AvailableMachineCondition) {
 	*out = *in
 	out.Usage = in.Usage
+	if in.NamespaceUsage != nil {
+		in, out := &in.NamespaceUsage, &out.NamespaceUsage
+		*out = make([]NamespaceUsageCondition, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailableMachineCondition.
@@ -42,10 +48,42 @@ func (in *AvailableMachineCondition) DeepCopy() *AvailableMachineCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainPolicy) DeepCopyInto(out *DrainPolicy) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeadlineSeconds != nil {
+		in, out := &in.DeadlineSeconds, &out.DeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainPolicy.
+func (in *DrainPolicy) DeepCopy() *DrainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPUSpec) DeepCopyInto(out *GPUSpec) {
 	*out = *in
 	out.Num = in.Num.DeepCopy()
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make([]v1.NodeSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUSpec.
@@ -58,6 +96,26 @@ func (in *GPUSpec) DeepCopy() *GPUSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectionPolicy) DeepCopyInto(out *InjectionPolicy) {
+	*out = *in
+	if in.LimitPercentage != nil {
+		in, out := &in.LimitPercentage, &out.LimitPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectionPolicy.
+func (in *InjectionPolicy) DeepCopy() *InjectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Machine) DeepCopyInto(out *Machine) {
 	*out = *in
@@ -208,11 +266,23 @@ func (in *MachineNodePoolSpec) DeepCopyInto(out *MachineNodePoolSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make([]NodePoolSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.MachineTypeStock != nil {
 		in, out := &in.MachineTypeStock, &out.MachineTypeStock
 		*out = make([]NodePoolMachineTypeStock, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodeHealthPolicy != nil {
+		in, out := &in.NodeHealthPolicy, &out.NodeHealthPolicy
+		*out = new(NodeHealthPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineNodePoolSpec.
@@ -230,7 +300,7 @@ func (in *MachineNodePoolStatus) DeepCopyInto(out *MachineNodePoolStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -238,7 +308,9 @@ func (in *MachineNodePoolStatus) DeepCopyInto(out *MachineNodePoolStatus) {
 	if in.NodePoolCondition != nil {
 		in, out := &in.NodePoolCondition, &out.NodePoolCondition
 		*out = make([]NodePoolCondition, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -252,6 +324,84 @@ func (in *MachineNodePoolStatus) DeepCopy() *MachineNodePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineQuota) DeepCopyInto(out *MachineQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineQuota.
+func (in *MachineQuota) DeepCopy() *MachineQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MachineQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineQuotaList) DeepCopyInto(out *MachineQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MachineQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineQuotaList.
+func (in *MachineQuotaList) DeepCopy() *MachineQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MachineQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineQuotaSpec) DeepCopyInto(out *MachineQuotaSpec) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make([]MachineTypeQuota, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineQuotaSpec.
+func (in *MachineQuotaSpec) DeepCopy() *MachineQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineSpec) DeepCopyInto(out *MachineSpec) {
 	*out = *in
@@ -262,6 +412,13 @@ func (in *MachineSpec) DeepCopyInto(out *MachineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make([]NodePoolSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.MachineTypes != nil {
 		in, out := &in.MachineTypes, &out.MachineTypes
 		*out = make([]MachineType, len(*in))
@@ -269,6 +426,11 @@ func (in *MachineSpec) DeepCopyInto(out *MachineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeHealthPolicy != nil {
+		in, out := &in.NodeHealthPolicy, &out.NodeHealthPolicy
+		*out = new(NodeHealthPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSpec.
@@ -286,7 +448,7 @@ func (in *MachineStatus) DeepCopyInto(out *MachineStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -294,7 +456,16 @@ func (in *MachineStatus) DeepCopyInto(out *MachineStatus) {
 	if in.AvailableMachines != nil {
 		in, out := &in.AvailableMachines, &out.AvailableMachines
 		*out = make([]AvailableMachineCondition, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeUsageCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -312,6 +483,11 @@ func (in *MachineStatus) DeepCopy() *MachineStatus {
 func (in *MachineType) DeepCopyInto(out *MachineType) {
 	*out = *in
 	in.Spec.DeepCopyInto(&out.Spec)
+	if in.InjectionPolicy != nil {
+		in, out := &in.InjectionPolicy, &out.InjectionPolicy
+		*out = new(InjectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineType.
@@ -324,6 +500,76 @@ func (in *MachineType) DeepCopy() *MachineType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineTypeQuota) DeepCopyInto(out *MachineTypeQuota) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineTypeQuota.
+func (in *MachineTypeQuota) DeepCopy() *MachineTypeQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineTypeQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceUsageCondition) DeepCopyInto(out *NamespaceUsageCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceUsageCondition.
+func (in *NamespaceUsageCondition) DeepCopy() *NamespaceUsageCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceUsageCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthPolicy) DeepCopyInto(out *NodeHealthPolicy) {
+	*out = *in
+	if in.UnhealthyConditions != nil {
+		in, out := &in.UnhealthyConditions, &out.UnhealthyConditions
+		*out = make([]UnhealthyNodeCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnhealthyTaints != nil {
+		in, out := &in.UnhealthyTaints, &out.UnhealthyTaints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthPolicy.
+func (in *NodeHealthPolicy) DeepCopy() *NodeHealthPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMachineTypeUsage) DeepCopyInto(out *NodeMachineTypeUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMachineTypeUsage.
+func (in *NodeMachineTypeUsage) DeepCopy() *NodeMachineTypeUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMachineTypeUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePool) DeepCopyInto(out *NodePool) {
 	*out = *in
@@ -332,6 +578,11 @@ func (in *NodePool) DeepCopyInto(out *NodePool) {
 		*out = make([]NodePoolMachineType, len(*in))
 		copy(*out, *in)
 	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
@@ -347,6 +598,10 @@ func (in *NodePool) DeepCopy() *NodePool {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePoolCondition) DeepCopyInto(out *NodePoolCondition) {
 	*out = *in
+	if in.DrainStartTime != nil {
+		in, out := &in.DrainStartTime, &out.DrainStartTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolCondition.
@@ -389,6 +644,71 @@ func (in *NodePoolMachineTypeStock) DeepCopy() *NodePoolMachineTypeStock {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolSelector) DeepCopyInto(out *NodePoolSelector) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineType != nil {
+		in, out := &in.MachineType, &out.MachineType
+		*out = make([]NodePoolMachineType, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolSelector.
+func (in *NodePoolSelector) DeepCopy() *NodePoolSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeUsageCondition) DeepCopyInto(out *NodeUsageCondition) {
+	*out = *in
+	if in.MachineTypes != nil {
+		in, out := &in.MachineTypes, &out.MachineTypes
+		*out = make([]NodeMachineTypeUsage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeUsageCondition.
+func (in *NodeUsageCondition) DeepCopy() *NodeUsageCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeUsageCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnhealthyNodeCondition) DeepCopyInto(out *UnhealthyNodeCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyNodeCondition.
+func (in *UnhealthyNodeCondition) DeepCopy() *UnhealthyNodeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(UnhealthyNodeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UsageCondition) DeepCopyInto(out *UsageCondition) {
 	*out = *in
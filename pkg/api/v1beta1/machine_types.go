@@ -0,0 +1,262 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MachineSpec defines the desired state of Machine
+type MachineSpec struct {
+
+	// NodePool is node list that machineGroup is managing.
+	// +optional
+	NodePool []NodePool `json:"nodePool,omitempty"`
+
+	// NodeSelector selects nodes that machineGroup is managing by labels.
+	// +optional
+	NodeSelector []NodePoolSelector `json:"nodeSelector,omitempty"`
+
+	// +kubebuilder:validation:Required
+	MachineTypes []MachineType `json:"machineTypes"`
+
+	// NodeHealthPolicy is additional conditions and taints to treat nodes as not-ready.
+	// +optional
+	NodeHealthPolicy *NodeHealthPolicy `json:"nodeHealthPolicy,omitempty"`
+
+	// ReservationMode is how to reserve resources for machineTypes.
+	// Sleeper (default) scales sleeper Pods down when Guest Pods are waiting,
+	// and Preemptible lets kube-scheduler preempt low-priority placeholder Pods with Guest Pods.
+	// +kubebuilder:validation:Enum=Sleeper;Preemptible
+	// +optional
+	ReservationMode ReservationMode `json:"reservationMode,omitempty"`
+
+	// ReportNodeStatus reports the number of reservation Pods and Guest Pods on each Node in .status.nodes.
+	// +optional
+	ReportNodeStatus bool `json:"reportNodeStatus,omitempty"`
+}
+
+type ReservationMode string
+
+const (
+	ReservationModeSleeper     ReservationMode = "Sleeper"
+	ReservationModePreemptible ReservationMode = "Preemptible"
+)
+
+type MachineType struct {
+
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	Spec MachineDetailSpec `json:"spec"`
+
+	// Available is the number of machineType units that the machineGroup provides.
+	// It is ignored when autoCapacity is true.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	Available int32 `json:"available"`
+
+	// AutoCapacity calculates the number of machineType units from allocatable resources of healthy Nodes
+	// in ready mode, excluding resources requested by DaemonSet Pods.
+	// +optional
+	AutoCapacity bool `json:"autoCapacity,omitempty"`
+
+	// InjectionPolicy is how to inject resources of the machineType into Guest Pods and reservation Pods.
+	// +optional
+	InjectionPolicy *InjectionPolicy `json:"injectionPolicy,omitempty"`
+}
+
+type InjectionPolicy struct {
+
+	// Type is Guaranteed (default), RequestsOnly or Burstable.
+	// Guaranteed sets limits equal to requests, RequestsOnly sets only requests for CPU and memory,
+	// and Burstable sets limits of CPU and memory to requests multiplied by limitPercentage.
+	// Limits of GPUs are always equal to requests since extended resources can not be overcommitted.
+	// +kubebuilder:validation:Enum=Guaranteed;RequestsOnly;Burstable
+	// +optional
+	Type InjectionPolicyType `json:"type,omitempty"`
+
+	// LimitPercentage is the percentage of limits to requests for CPU and memory. It is required in Burstable.
+	// +kubebuilder:validation:Minimum:=100
+	// +optional
+	LimitPercentage *int32 `json:"limitPercentage,omitempty"`
+}
+
+type InjectionPolicyType string
+
+const (
+	InjectionPolicyGuaranteed   InjectionPolicyType = "Guaranteed"
+	InjectionPolicyRequestsOnly InjectionPolicyType = "RequestsOnly"
+	InjectionPolicyBurstable    InjectionPolicyType = "Burstable"
+)
+
+type MachineDetailSpec struct {
+
+	// +kubebuilder:validation:Required
+	CPU resource.Quantity `json:"cpu"`
+
+	// +kubebuilder:validation:Required
+	Memory resource.Quantity `json:"memory"`
+
+	// +optional
+	GPU *GPUSpec `json:"gpu,omitempty"`
+}
+
+type GPUSpec struct {
+
+	// Type is the extended resource name of the accelerator, e.g. nvidia.com/gpu, amd.com/gpu or gpu.intel.com/i915.
+	// +optional
+	Type corev1.ResourceName `json:"type,omitempty"`
+
+	// +optional
+	Num resource.Quantity `json:"num,omitempty"`
+
+	// nvidia.com/gpu.family
+	// +optional
+	Family string `json:"family,omitempty"`
+
+	// nvidia.com/gpu.product
+	// +optional
+	Product string `json:"product,omitempty"`
+
+	// nvidia.com/gpu.machine
+	// +optional
+	Machine string `json:"machine,omitempty"`
+
+	// Selector selects Nodes with the accelerator by labels, e.g. labels of AMD or Intel GPU device plugins.
+	// It can be set with or instead of family, product and machine.
+	// +optional
+	Selector []corev1.NodeSelectorRequirement `json:"selector,omitempty"`
+}
+
+// MachineStatus defines the observed state of Machine
+type MachineStatus struct {
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	AvailableMachines []AvailableMachineCondition `json:"availableMachines,omitempty"`
+
+	// Nodes is the usage of machineTypes on each Node. It is reported only when reportNodeStatus is true.
+	// +optional
+	Nodes []NodeUsageCondition `json:"nodes,omitempty"`
+}
+
+type AvailableMachineCondition struct {
+
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	Usage UsageCondition `json:"usage"`
+
+	// NamespaceUsage is the number of machineType units used in each namespace.
+	// +optional
+	NamespaceUsage []NamespaceUsageCondition `json:"namespaceUsage,omitempty"`
+}
+
+type NodeUsageCondition struct {
+
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Condition is the condition of the Node in MachineNodePool.
+	// +optional
+	Condition MachineNodeCondition `json:"condition,omitempty"`
+
+	// MachineTypes is the number of reservation Pods and Guest Pods for each machineType served by the Node.
+	// +optional
+	MachineTypes []NodeMachineTypeUsage `json:"machineTypes,omitempty"`
+}
+
+type NodeMachineTypeUsage struct {
+
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum:=0
+	Reserved int32 `json:"reserved"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum:=0
+	Used int32 `json:"used"`
+}
+
+type NamespaceUsageCondition struct {
+
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum:=0
+	Used int32 `json:"used"`
+}
+
+type UsageCondition struct {
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum:=0
+	Maximum int32 `json:"maximum"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum:=0
+	Reserved int32 `json:"reserved"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum:=0
+	Used int32 `json:"used"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum:=0
+	Waiting int32 `json:"waiting"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Group",type="string",JSONPath=`.metadata.labels['imperator\.tenzen-y\.io/machine-group']`
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=='Ready')].status`
+
+// Machine is the Schema for the machines API
+type Machine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MachineSpec   `json:"spec,omitempty"`
+	Status MachineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MachineList contains a list of Machine
+type MachineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Machine `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Machine{}, &MachineList{})
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MachineNodePoolSpec defines the desired state of MachineNodePool
+type MachineNodePoolSpec struct {
+
+	// MachineGroupName is node pool group
+	// +kubebuilder:validation:Required
+	MachineGroupName string `json:"machineGroupName"`
+
+	// NodePool is node list that machineGroup is managing.
+	// +optional
+	NodePool []NodePool `json:"nodePool,omitempty"`
+
+	// NodeSelector selects nodes that machineGroup is managing by labels.
+	// +optional
+	NodeSelector []NodePoolSelector `json:"nodeSelector,omitempty"`
+
+	// NodeHealthPolicy is additional conditions and taints to treat nodes as not-ready.
+	// +optional
+	NodeHealthPolicy *NodeHealthPolicy `json:"nodeHealthPolicy,omitempty"`
+}
+
+type NodePool struct {
+
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	Mode NodePoolMode `json:"mode"`
+
+	// +optional
+	// default=false
+	Taint bool `json:"taint,omitempty"`
+
+	// +kubebuilder:validation:Required
+	MachineType []NodePoolMachineType `json:"machineType"`
+
+	// Drain evicts Guest Pods from the Node when mode is maintenance.
+	// +optional
+	Drain *DrainPolicy `json:"drain,omitempty"`
+}
+
+// NodePoolSelector is used to add all Nodes matching labelSelector to the pool.
+type NodePoolSelector struct {
+
+	// +kubebuilder:validation:Required
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+
+	// +kubebuilder:validation:Required
+	Mode NodePoolMode `json:"mode"`
+
+	// +optional
+	// default=false
+	Taint bool `json:"taint,omitempty"`
+
+	// +kubebuilder:validation:Required
+	MachineType []NodePoolMachineType `json:"machineType"`
+
+	// Drain evicts Guest Pods from the Node when mode is maintenance.
+	// +optional
+	Drain *DrainPolicy `json:"drain,omitempty"`
+}
+
+// DrainPolicy defines how to evict Guest Pods from Nodes in maintenance mode.
+// Guest Pods are evicted through the Eviction API, so PodDisruptionBudgets are respected until DeadlineSeconds.
+type DrainPolicy struct {
+
+	// GracePeriodSeconds is the grace period for evicted Guest Pods.
+	// If it is not set, terminationGracePeriodSeconds of the Pod is used.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// DeadlineSeconds is how long to wait for PodDisruptionBudgets to allow evictions.
+	// Remaining Guest Pods are deleted without the Eviction API after the deadline,
+	// so PodDisruptionBudgets are not honoured after the deadline. If it is not set, Guest Pods are evicted forever.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	DeadlineSeconds *int64 `json:"deadlineSeconds,omitempty"`
+}
+
+// NodeHealthPolicy defines conditions and taints to treat nodes as not-ready.
+// Those are added to the built-in policy; Ready is False or Unknown, MemoryPressure, DiskPressure or PIDPressure is True,
+// and the Node has node.kubernetes.io/unschedulable or node.kubernetes.io/network-unavailable taint.
+type NodeHealthPolicy struct {
+
+	// UnhealthyConditions is Node conditions to treat nodes as not-ready.
+	// e.g. conditions reported by node-problem-detector.
+	// +optional
+	UnhealthyConditions []UnhealthyNodeCondition `json:"unhealthyConditions,omitempty"`
+
+	// UnhealthyTaints is taint keys to treat nodes as not-ready.
+	// +optional
+	UnhealthyTaints []string `json:"unhealthyTaints,omitempty"`
+}
+
+type UnhealthyNodeCondition struct {
+
+	// +kubebuilder:validation:Required
+	Type corev1.NodeConditionType `json:"type"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Status corev1.ConditionStatus `json:"status"`
+}
+
+type NodePoolMachineType struct {
+
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+}
+
+// NodePoolMode is the mode of Nodes specified by users.
+// Nodes which are not healthy are labeled with not-ready by imperator regardless of the mode.
+// +kubebuilder:validation:Enum=ready;maintenance
+type NodePoolMode string
+
+const (
+	NodeModeReady       NodePoolMode = "ready"
+	NodeModeMaintenance NodePoolMode = "maintenance"
+)
+
+func (mode NodePoolMode) Value() string {
+	return string(mode)
+}
+
+// MachineNodePoolStatus defines the observed state of MachineNodePool
+type MachineNodePoolStatus struct {
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +optional
+	NodePoolCondition []NodePoolCondition `json:"nodePool,omitempty"`
+}
+
+type NodePoolCondition struct {
+
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// +optional
+	NodeCondition MachineNodeCondition `json:"condition,omitempty"`
+
+	// Reason is why the Node is unhealthy.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// DrainStartTime is when imperator started to drain Guest Pods from the Node.
+	// +optional
+	DrainStartTime *metav1.Time `json:"drainStartTime,omitempty"`
+}
+
+// MachineNodeCondition is condition of Kubernetes Nodes
+// +kubebuilder:validation:Enum=Healthy;Maintenance;Unhealthy;Missing;Draining;Drained
+type MachineNodeCondition string
+
+const (
+	NodeHealthy     MachineNodeCondition = "Healthy"
+	NodeMaintenance MachineNodeCondition = "Maintenance"
+	NodeUnhealthy   MachineNodeCondition = "Unhealthy"
+	// NodeMissing means the Node specified in nodePool does not exist in the cluster.
+	NodeMissing MachineNodeCondition = "Missing"
+	// NodeDraining means the Node is in maintenance mode, and Guest Pods are being evicted.
+	NodeDraining MachineNodeCondition = "Draining"
+	// NodeDrained means the Node is in maintenance mode, and all Guest Pods were evicted.
+	NodeDrained MachineNodeCondition = "Drained"
+)
+
+const (
+	ConditionReady = "Ready"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Group",type="string",JSONPath=`.spec.machineGroupName`
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=='Ready')].status`
+
+// MachineNodePool is the Schema for the machinenodepools API
+type MachineNodePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MachineNodePoolSpec   `json:"spec,omitempty"`
+	Status MachineNodePoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MachineNodePoolList contains a list of MachineNodePool
+type MachineNodePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MachineNodePool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MachineNodePool{}, &MachineNodePoolList{})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// AvailableMachineConditionApplyConfiguration represents an declarative configuration of the AvailableMachineCondition type for use
+// with apply.
+type AvailableMachineConditionApplyConfiguration struct {
+	Name           *string                                     `json:"name,omitempty"`
+	Usage          *UsageConditionApplyConfiguration           `json:"usage,omitempty"`
+	NamespaceUsage []NamespaceUsageConditionApplyConfiguration `json:"namespaceUsage,omitempty"`
+}
+
+// AvailableMachineConditionApplyConfiguration constructs an declarative configuration of the AvailableMachineCondition type for use with
+// apply.
+func AvailableMachineCondition() *AvailableMachineConditionApplyConfiguration {
+	return &AvailableMachineConditionApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *AvailableMachineConditionApplyConfiguration) WithName(value string) *AvailableMachineConditionApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithUsage sets the Usage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Usage field is set to the value of the last call.
+func (b *AvailableMachineConditionApplyConfiguration) WithUsage(value *UsageConditionApplyConfiguration) *AvailableMachineConditionApplyConfiguration {
+	b.Usage = value
+	return b
+}
+
+// WithNamespaceUsage adds the given value to the NamespaceUsage field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NamespaceUsage field.
+func (b *AvailableMachineConditionApplyConfiguration) WithNamespaceUsage(values ...*NamespaceUsageConditionApplyConfiguration) *AvailableMachineConditionApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNamespaceUsage")
+		}
+		b.NamespaceUsage = append(b.NamespaceUsage, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
+)
+
+// InjectionPolicyApplyConfiguration represents an declarative configuration of the InjectionPolicy type for use
+// with apply.
+type InjectionPolicyApplyConfiguration struct {
+	Type            *v1alpha1.InjectionPolicyType `json:"type,omitempty"`
+	LimitPercentage *int32                        `json:"limitPercentage,omitempty"`
+}
+
+// InjectionPolicyApplyConfiguration constructs an declarative configuration of the InjectionPolicy type for use with
+// apply.
+func InjectionPolicy() *InjectionPolicyApplyConfiguration {
+	return &InjectionPolicyApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *InjectionPolicyApplyConfiguration) WithType(value v1alpha1.InjectionPolicyType) *InjectionPolicyApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithLimitPercentage sets the LimitPercentage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LimitPercentage field is set to the value of the last call.
+func (b *InjectionPolicyApplyConfiguration) WithLimitPercentage(value int32) *InjectionPolicyApplyConfiguration {
+	b.LimitPercentage = &value
+	return b
+}
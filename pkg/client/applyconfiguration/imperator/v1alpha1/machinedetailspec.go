@@ -0,0 +1,61 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// MachineDetailSpecApplyConfiguration represents an declarative configuration of the MachineDetailSpec type for use
+// with apply.
+type MachineDetailSpecApplyConfiguration struct {
+	CPU    *resource.Quantity         `json:"cpu,omitempty"`
+	Memory *resource.Quantity         `json:"memory,omitempty"`
+	GPU    *GPUSpecApplyConfiguration `json:"gpu,omitempty"`
+}
+
+// MachineDetailSpecApplyConfiguration constructs an declarative configuration of the MachineDetailSpec type for use with
+// apply.
+func MachineDetailSpec() *MachineDetailSpecApplyConfiguration {
+	return &MachineDetailSpecApplyConfiguration{}
+}
+
+// WithCPU sets the CPU field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CPU field is set to the value of the last call.
+func (b *MachineDetailSpecApplyConfiguration) WithCPU(value resource.Quantity) *MachineDetailSpecApplyConfiguration {
+	b.CPU = &value
+	return b
+}
+
+// WithMemory sets the Memory field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Memory field is set to the value of the last call.
+func (b *MachineDetailSpecApplyConfiguration) WithMemory(value resource.Quantity) *MachineDetailSpecApplyConfiguration {
+	b.Memory = &value
+	return b
+}
+
+// WithGPU sets the GPU field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GPU field is set to the value of the last call.
+func (b *MachineDetailSpecApplyConfiguration) WithGPU(value *GPUSpecApplyConfiguration) *MachineDetailSpecApplyConfiguration {
+	b.GPU = value
+	return b
+}
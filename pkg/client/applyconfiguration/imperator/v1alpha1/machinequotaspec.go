@@ -0,0 +1,53 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// MachineQuotaSpecApplyConfiguration represents an declarative configuration of the MachineQuotaSpec type for use
+// with apply.
+type MachineQuotaSpecApplyConfiguration struct {
+	MachineGroup *string                              `json:"machineGroup,omitempty"`
+	Hard         []MachineTypeQuotaApplyConfiguration `json:"hard,omitempty"`
+}
+
+// MachineQuotaSpecApplyConfiguration constructs an declarative configuration of the MachineQuotaSpec type for use with
+// apply.
+func MachineQuotaSpec() *MachineQuotaSpecApplyConfiguration {
+	return &MachineQuotaSpecApplyConfiguration{}
+}
+
+// WithMachineGroup sets the MachineGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MachineGroup field is set to the value of the last call.
+func (b *MachineQuotaSpecApplyConfiguration) WithMachineGroup(value string) *MachineQuotaSpecApplyConfiguration {
+	b.MachineGroup = &value
+	return b
+}
+
+// WithHard adds the given value to the Hard field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Hard field.
+func (b *MachineQuotaSpecApplyConfiguration) WithHard(values ...*MachineTypeQuotaApplyConfiguration) *MachineQuotaSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithHard")
+		}
+		b.Hard = append(b.Hard, *values[i])
+	}
+	return b
+}
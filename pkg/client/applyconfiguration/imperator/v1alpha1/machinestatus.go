@@ -0,0 +1,73 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MachineStatusApplyConfiguration represents an declarative configuration of the MachineStatus type for use
+// with apply.
+type MachineStatusApplyConfiguration struct {
+	Conditions        []v1.Condition                                `json:"conditions,omitempty"`
+	AvailableMachines []AvailableMachineConditionApplyConfiguration `json:"availableMachines,omitempty"`
+	Nodes             []NodeUsageConditionApplyConfiguration        `json:"nodes,omitempty"`
+}
+
+// MachineStatusApplyConfiguration constructs an declarative configuration of the MachineStatus type for use with
+// apply.
+func MachineStatus() *MachineStatusApplyConfiguration {
+	return &MachineStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *MachineStatusApplyConfiguration) WithConditions(values ...v1.Condition) *MachineStatusApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
+
+// WithAvailableMachines adds the given value to the AvailableMachines field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AvailableMachines field.
+func (b *MachineStatusApplyConfiguration) WithAvailableMachines(values ...*AvailableMachineConditionApplyConfiguration) *MachineStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAvailableMachines")
+		}
+		b.AvailableMachines = append(b.AvailableMachines, *values[i])
+	}
+	return b
+}
+
+// WithNodes adds the given value to the Nodes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Nodes field.
+func (b *MachineStatusApplyConfiguration) WithNodes(values ...*NodeUsageConditionApplyConfiguration) *MachineStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNodes")
+		}
+		b.Nodes = append(b.Nodes, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// MachineTypeApplyConfiguration represents an declarative configuration of the MachineType type for use
+// with apply.
+type MachineTypeApplyConfiguration struct {
+	Name            *string                              `json:"name,omitempty"`
+	Spec            *MachineDetailSpecApplyConfiguration `json:"spec,omitempty"`
+	Available       *int32                               `json:"available,omitempty"`
+	AutoCapacity    *bool                                `json:"autoCapacity,omitempty"`
+	InjectionPolicy *InjectionPolicyApplyConfiguration   `json:"injectionPolicy,omitempty"`
+}
+
+// MachineTypeApplyConfiguration constructs an declarative configuration of the MachineType type for use with
+// apply.
+func MachineType() *MachineTypeApplyConfiguration {
+	return &MachineTypeApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *MachineTypeApplyConfiguration) WithName(value string) *MachineTypeApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithSpec sets the Spec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spec field is set to the value of the last call.
+func (b *MachineTypeApplyConfiguration) WithSpec(value *MachineDetailSpecApplyConfiguration) *MachineTypeApplyConfiguration {
+	b.Spec = value
+	return b
+}
+
+// WithAvailable sets the Available field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Available field is set to the value of the last call.
+func (b *MachineTypeApplyConfiguration) WithAvailable(value int32) *MachineTypeApplyConfiguration {
+	b.Available = &value
+	return b
+}
+
+// WithAutoCapacity sets the AutoCapacity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutoCapacity field is set to the value of the last call.
+func (b *MachineTypeApplyConfiguration) WithAutoCapacity(value bool) *MachineTypeApplyConfiguration {
+	b.AutoCapacity = &value
+	return b
+}
+
+// WithInjectionPolicy sets the InjectionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InjectionPolicy field is set to the value of the last call.
+func (b *MachineTypeApplyConfiguration) WithInjectionPolicy(value *InjectionPolicyApplyConfiguration) *MachineTypeApplyConfiguration {
+	b.InjectionPolicy = value
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// NodeMachineTypeUsageApplyConfiguration represents an declarative configuration of the NodeMachineTypeUsage type for use
+// with apply.
+type NodeMachineTypeUsageApplyConfiguration struct {
+	Name     *string `json:"name,omitempty"`
+	Reserved *int32  `json:"reserved,omitempty"`
+	Used     *int32  `json:"used,omitempty"`
+}
+
+// NodeMachineTypeUsageApplyConfiguration constructs an declarative configuration of the NodeMachineTypeUsage type for use with
+// apply.
+func NodeMachineTypeUsage() *NodeMachineTypeUsageApplyConfiguration {
+	return &NodeMachineTypeUsageApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *NodeMachineTypeUsageApplyConfiguration) WithName(value string) *NodeMachineTypeUsageApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithReserved sets the Reserved field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reserved field is set to the value of the last call.
+func (b *NodeMachineTypeUsageApplyConfiguration) WithReserved(value int32) *NodeMachineTypeUsageApplyConfiguration {
+	b.Reserved = &value
+	return b
+}
+
+// WithUsed sets the Used field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Used field is set to the value of the last call.
+func (b *NodeMachineTypeUsageApplyConfiguration) WithUsed(value int32) *NodeMachineTypeUsageApplyConfiguration {
+	b.Used = &value
+	return b
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodePoolConditionApplyConfiguration represents an declarative configuration of the NodePoolCondition type for use
+// with apply.
+type NodePoolConditionApplyConfiguration struct {
+	Name           *string                        `json:"name,omitempty"`
+	NodeCondition  *v1alpha1.MachineNodeCondition `json:"condition,omitempty"`
+	Reason         *string                        `json:"reason,omitempty"`
+	DrainStartTime *v1.Time                       `json:"drainStartTime,omitempty"`
+}
+
+// NodePoolConditionApplyConfiguration constructs an declarative configuration of the NodePoolCondition type for use with
+// apply.
+func NodePoolCondition() *NodePoolConditionApplyConfiguration {
+	return &NodePoolConditionApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *NodePoolConditionApplyConfiguration) WithName(value string) *NodePoolConditionApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNodeCondition sets the NodeCondition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeCondition field is set to the value of the last call.
+func (b *NodePoolConditionApplyConfiguration) WithNodeCondition(value v1alpha1.MachineNodeCondition) *NodePoolConditionApplyConfiguration {
+	b.NodeCondition = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *NodePoolConditionApplyConfiguration) WithReason(value string) *NodePoolConditionApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithDrainStartTime sets the DrainStartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrainStartTime field is set to the value of the last call.
+func (b *NodePoolConditionApplyConfiguration) WithDrainStartTime(value v1.Time) *NodePoolConditionApplyConfiguration {
+	b.DrainStartTime = &value
+	return b
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodePoolSelectorApplyConfiguration represents an declarative configuration of the NodePoolSelector type for use
+// with apply.
+type NodePoolSelectorApplyConfiguration struct {
+	LabelSelector *v1.LabelSelector                       `json:"labelSelector,omitempty"`
+	Mode          *v1alpha1.NodePoolMode                  `json:"mode,omitempty"`
+	Taint         *bool                                   `json:"taint,omitempty"`
+	MachineType   []NodePoolMachineTypeApplyConfiguration `json:"machineType,omitempty"`
+	Drain         *DrainPolicyApplyConfiguration          `json:"drain,omitempty"`
+}
+
+// NodePoolSelectorApplyConfiguration constructs an declarative configuration of the NodePoolSelector type for use with
+// apply.
+func NodePoolSelector() *NodePoolSelectorApplyConfiguration {
+	return &NodePoolSelectorApplyConfiguration{}
+}
+
+// WithLabelSelector sets the LabelSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LabelSelector field is set to the value of the last call.
+func (b *NodePoolSelectorApplyConfiguration) WithLabelSelector(value v1.LabelSelector) *NodePoolSelectorApplyConfiguration {
+	b.LabelSelector = &value
+	return b
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *NodePoolSelectorApplyConfiguration) WithMode(value v1alpha1.NodePoolMode) *NodePoolSelectorApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithTaint sets the Taint field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Taint field is set to the value of the last call.
+func (b *NodePoolSelectorApplyConfiguration) WithTaint(value bool) *NodePoolSelectorApplyConfiguration {
+	b.Taint = &value
+	return b
+}
+
+// WithMachineType adds the given value to the MachineType field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the MachineType field.
+func (b *NodePoolSelectorApplyConfiguration) WithMachineType(values ...*NodePoolMachineTypeApplyConfiguration) *NodePoolSelectorApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMachineType")
+		}
+		b.MachineType = append(b.MachineType, *values[i])
+	}
+	return b
+}
+
+// WithDrain sets the Drain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Drain field is set to the value of the last call.
+func (b *NodePoolSelectorApplyConfiguration) WithDrain(value *DrainPolicyApplyConfiguration) *NodePoolSelectorApplyConfiguration {
+	b.Drain = value
+	return b
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// UsageConditionApplyConfiguration represents an declarative configuration of the UsageCondition type for use
+// with apply.
+type UsageConditionApplyConfiguration struct {
+	Maximum  *int32 `json:"maximum,omitempty"`
+	Reserved *int32 `json:"reserved,omitempty"`
+	Used     *int32 `json:"used,omitempty"`
+	Waiting  *int32 `json:"waiting,omitempty"`
+}
+
+// UsageConditionApplyConfiguration constructs an declarative configuration of the UsageCondition type for use with
+// apply.
+func UsageCondition() *UsageConditionApplyConfiguration {
+	return &UsageConditionApplyConfiguration{}
+}
+
+// WithMaximum sets the Maximum field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Maximum field is set to the value of the last call.
+func (b *UsageConditionApplyConfiguration) WithMaximum(value int32) *UsageConditionApplyConfiguration {
+	b.Maximum = &value
+	return b
+}
+
+// WithReserved sets the Reserved field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reserved field is set to the value of the last call.
+func (b *UsageConditionApplyConfiguration) WithReserved(value int32) *UsageConditionApplyConfiguration {
+	b.Reserved = &value
+	return b
+}
+
+// WithUsed sets the Used field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Used field is set to the value of the last call.
+func (b *UsageConditionApplyConfiguration) WithUsed(value int32) *UsageConditionApplyConfiguration {
+	b.Used = &value
+	return b
+}
+
+// WithWaiting sets the Waiting field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Waiting field is set to the value of the last call.
+func (b *UsageConditionApplyConfiguration) WithWaiting(value int32) *UsageConditionApplyConfiguration {
+	b.Waiting = &value
+	return b
+}
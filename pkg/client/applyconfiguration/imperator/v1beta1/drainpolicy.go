@@ -0,0 +1,48 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// DrainPolicyApplyConfiguration represents an declarative configuration of the DrainPolicy type for use
+// with apply.
+type DrainPolicyApplyConfiguration struct {
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+	DeadlineSeconds    *int64 `json:"deadlineSeconds,omitempty"`
+}
+
+// DrainPolicyApplyConfiguration constructs an declarative configuration of the DrainPolicy type for use with
+// apply.
+func DrainPolicy() *DrainPolicyApplyConfiguration {
+	return &DrainPolicyApplyConfiguration{}
+}
+
+// WithGracePeriodSeconds sets the GracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GracePeriodSeconds field is set to the value of the last call.
+func (b *DrainPolicyApplyConfiguration) WithGracePeriodSeconds(value int64) *DrainPolicyApplyConfiguration {
+	b.GracePeriodSeconds = &value
+	return b
+}
+
+// WithDeadlineSeconds sets the DeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeadlineSeconds field is set to the value of the last call.
+func (b *DrainPolicyApplyConfiguration) WithDeadlineSeconds(value int64) *DrainPolicyApplyConfiguration {
+	b.DeadlineSeconds = &value
+	return b
+}
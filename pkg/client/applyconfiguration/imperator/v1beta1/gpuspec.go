@@ -0,0 +1,91 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// GPUSpecApplyConfiguration represents an declarative configuration of the GPUSpec type for use
+// with apply.
+type GPUSpecApplyConfiguration struct {
+	Type     *v1.ResourceName             `json:"type,omitempty"`
+	Num      *resource.Quantity           `json:"num,omitempty"`
+	Family   *string                      `json:"family,omitempty"`
+	Product  *string                      `json:"product,omitempty"`
+	Machine  *string                      `json:"machine,omitempty"`
+	Selector []v1.NodeSelectorRequirement `json:"selector,omitempty"`
+}
+
+// GPUSpecApplyConfiguration constructs an declarative configuration of the GPUSpec type for use with
+// apply.
+func GPUSpec() *GPUSpecApplyConfiguration {
+	return &GPUSpecApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *GPUSpecApplyConfiguration) WithType(value v1.ResourceName) *GPUSpecApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithNum sets the Num field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Num field is set to the value of the last call.
+func (b *GPUSpecApplyConfiguration) WithNum(value resource.Quantity) *GPUSpecApplyConfiguration {
+	b.Num = &value
+	return b
+}
+
+// WithFamily sets the Family field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Family field is set to the value of the last call.
+func (b *GPUSpecApplyConfiguration) WithFamily(value string) *GPUSpecApplyConfiguration {
+	b.Family = &value
+	return b
+}
+
+// WithProduct sets the Product field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Product field is set to the value of the last call.
+func (b *GPUSpecApplyConfiguration) WithProduct(value string) *GPUSpecApplyConfiguration {
+	b.Product = &value
+	return b
+}
+
+// WithMachine sets the Machine field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Machine field is set to the value of the last call.
+func (b *GPUSpecApplyConfiguration) WithMachine(value string) *GPUSpecApplyConfiguration {
+	b.Machine = &value
+	return b
+}
+
+// WithSelector adds the given value to the Selector field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Selector field.
+func (b *GPUSpecApplyConfiguration) WithSelector(values ...v1.NodeSelectorRequirement) *GPUSpecApplyConfiguration {
+	for i := range values {
+		b.Selector = append(b.Selector, values[i])
+	}
+	return b
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// MachineNodePoolSpecApplyConfiguration represents an declarative configuration of the MachineNodePoolSpec type for use
+// with apply.
+type MachineNodePoolSpecApplyConfiguration struct {
+	MachineGroupName *string                              `json:"machineGroupName,omitempty"`
+	NodePool         []NodePoolApplyConfiguration         `json:"nodePool,omitempty"`
+	NodeSelector     []NodePoolSelectorApplyConfiguration `json:"nodeSelector,omitempty"`
+	NodeHealthPolicy *NodeHealthPolicyApplyConfiguration  `json:"nodeHealthPolicy,omitempty"`
+}
+
+// MachineNodePoolSpecApplyConfiguration constructs an declarative configuration of the MachineNodePoolSpec type for use with
+// apply.
+func MachineNodePoolSpec() *MachineNodePoolSpecApplyConfiguration {
+	return &MachineNodePoolSpecApplyConfiguration{}
+}
+
+// WithMachineGroupName sets the MachineGroupName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MachineGroupName field is set to the value of the last call.
+func (b *MachineNodePoolSpecApplyConfiguration) WithMachineGroupName(value string) *MachineNodePoolSpecApplyConfiguration {
+	b.MachineGroupName = &value
+	return b
+}
+
+// WithNodePool adds the given value to the NodePool field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NodePool field.
+func (b *MachineNodePoolSpecApplyConfiguration) WithNodePool(values ...*NodePoolApplyConfiguration) *MachineNodePoolSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNodePool")
+		}
+		b.NodePool = append(b.NodePool, *values[i])
+	}
+	return b
+}
+
+// WithNodeSelector adds the given value to the NodeSelector field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NodeSelector field.
+func (b *MachineNodePoolSpecApplyConfiguration) WithNodeSelector(values ...*NodePoolSelectorApplyConfiguration) *MachineNodePoolSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNodeSelector")
+		}
+		b.NodeSelector = append(b.NodeSelector, *values[i])
+	}
+	return b
+}
+
+// WithNodeHealthPolicy sets the NodeHealthPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeHealthPolicy field is set to the value of the last call.
+func (b *MachineNodePoolSpecApplyConfiguration) WithNodeHealthPolicy(value *NodeHealthPolicyApplyConfiguration) *MachineNodePoolSpecApplyConfiguration {
+	b.NodeHealthPolicy = value
+	return b
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MachineNodePoolStatusApplyConfiguration represents an declarative configuration of the MachineNodePoolStatus type for use
+// with apply.
+type MachineNodePoolStatusApplyConfiguration struct {
+	Conditions        []v1.Condition                        `json:"conditions,omitempty"`
+	NodePoolCondition []NodePoolConditionApplyConfiguration `json:"nodePool,omitempty"`
+}
+
+// MachineNodePoolStatusApplyConfiguration constructs an declarative configuration of the MachineNodePoolStatus type for use with
+// apply.
+func MachineNodePoolStatus() *MachineNodePoolStatusApplyConfiguration {
+	return &MachineNodePoolStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *MachineNodePoolStatusApplyConfiguration) WithConditions(values ...v1.Condition) *MachineNodePoolStatusApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
+
+// WithNodePoolCondition adds the given value to the NodePoolCondition field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NodePoolCondition field.
+func (b *MachineNodePoolStatusApplyConfiguration) WithNodePoolCondition(values ...*NodePoolConditionApplyConfiguration) *MachineNodePoolStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNodePoolCondition")
+		}
+		b.NodePoolCondition = append(b.NodePoolCondition, *values[i])
+	}
+	return b
+}
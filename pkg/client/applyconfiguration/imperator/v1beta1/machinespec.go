@@ -0,0 +1,103 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	imperatorv1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
+)
+
+// MachineSpecApplyConfiguration represents an declarative configuration of the MachineSpec type for use
+// with apply.
+type MachineSpecApplyConfiguration struct {
+	NodePool         []NodePoolApplyConfiguration         `json:"nodePool,omitempty"`
+	NodeSelector     []NodePoolSelectorApplyConfiguration `json:"nodeSelector,omitempty"`
+	MachineTypes     []MachineTypeApplyConfiguration      `json:"machineTypes,omitempty"`
+	NodeHealthPolicy *NodeHealthPolicyApplyConfiguration  `json:"nodeHealthPolicy,omitempty"`
+	ReservationMode  *imperatorv1beta1.ReservationMode    `json:"reservationMode,omitempty"`
+	ReportNodeStatus *bool                                `json:"reportNodeStatus,omitempty"`
+}
+
+// MachineSpecApplyConfiguration constructs an declarative configuration of the MachineSpec type for use with
+// apply.
+func MachineSpec() *MachineSpecApplyConfiguration {
+	return &MachineSpecApplyConfiguration{}
+}
+
+// WithNodePool adds the given value to the NodePool field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NodePool field.
+func (b *MachineSpecApplyConfiguration) WithNodePool(values ...*NodePoolApplyConfiguration) *MachineSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNodePool")
+		}
+		b.NodePool = append(b.NodePool, *values[i])
+	}
+	return b
+}
+
+// WithNodeSelector adds the given value to the NodeSelector field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NodeSelector field.
+func (b *MachineSpecApplyConfiguration) WithNodeSelector(values ...*NodePoolSelectorApplyConfiguration) *MachineSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNodeSelector")
+		}
+		b.NodeSelector = append(b.NodeSelector, *values[i])
+	}
+	return b
+}
+
+// WithMachineTypes adds the given value to the MachineTypes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the MachineTypes field.
+func (b *MachineSpecApplyConfiguration) WithMachineTypes(values ...*MachineTypeApplyConfiguration) *MachineSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMachineTypes")
+		}
+		b.MachineTypes = append(b.MachineTypes, *values[i])
+	}
+	return b
+}
+
+// WithNodeHealthPolicy sets the NodeHealthPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeHealthPolicy field is set to the value of the last call.
+func (b *MachineSpecApplyConfiguration) WithNodeHealthPolicy(value *NodeHealthPolicyApplyConfiguration) *MachineSpecApplyConfiguration {
+	b.NodeHealthPolicy = value
+	return b
+}
+
+// WithReservationMode sets the ReservationMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReservationMode field is set to the value of the last call.
+func (b *MachineSpecApplyConfiguration) WithReservationMode(value imperatorv1beta1.ReservationMode) *MachineSpecApplyConfiguration {
+	b.ReservationMode = &value
+	return b
+}
+
+// WithReportNodeStatus sets the ReportNodeStatus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReportNodeStatus field is set to the value of the last call.
+func (b *MachineSpecApplyConfiguration) WithReportNodeStatus(value bool) *MachineSpecApplyConfiguration {
+	b.ReportNodeStatus = &value
+	return b
+}
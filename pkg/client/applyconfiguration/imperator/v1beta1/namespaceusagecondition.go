@@ -0,0 +1,48 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// NamespaceUsageConditionApplyConfiguration represents an declarative configuration of the NamespaceUsageCondition type for use
+// with apply.
+type NamespaceUsageConditionApplyConfiguration struct {
+	Namespace *string `json:"namespace,omitempty"`
+	Used      *int32  `json:"used,omitempty"`
+}
+
+// NamespaceUsageConditionApplyConfiguration constructs an declarative configuration of the NamespaceUsageCondition type for use with
+// apply.
+func NamespaceUsageCondition() *NamespaceUsageConditionApplyConfiguration {
+	return &NamespaceUsageConditionApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *NamespaceUsageConditionApplyConfiguration) WithNamespace(value string) *NamespaceUsageConditionApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithUsed sets the Used field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Used field is set to the value of the last call.
+func (b *NamespaceUsageConditionApplyConfiguration) WithUsed(value int32) *NamespaceUsageConditionApplyConfiguration {
+	b.Used = &value
+	return b
+}
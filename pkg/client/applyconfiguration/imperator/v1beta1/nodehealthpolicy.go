@@ -0,0 +1,55 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// NodeHealthPolicyApplyConfiguration represents an declarative configuration of the NodeHealthPolicy type for use
+// with apply.
+type NodeHealthPolicyApplyConfiguration struct {
+	UnhealthyConditions []UnhealthyNodeConditionApplyConfiguration `json:"unhealthyConditions,omitempty"`
+	UnhealthyTaints     []string                                   `json:"unhealthyTaints,omitempty"`
+}
+
+// NodeHealthPolicyApplyConfiguration constructs an declarative configuration of the NodeHealthPolicy type for use with
+// apply.
+func NodeHealthPolicy() *NodeHealthPolicyApplyConfiguration {
+	return &NodeHealthPolicyApplyConfiguration{}
+}
+
+// WithUnhealthyConditions adds the given value to the UnhealthyConditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UnhealthyConditions field.
+func (b *NodeHealthPolicyApplyConfiguration) WithUnhealthyConditions(values ...*UnhealthyNodeConditionApplyConfiguration) *NodeHealthPolicyApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUnhealthyConditions")
+		}
+		b.UnhealthyConditions = append(b.UnhealthyConditions, *values[i])
+	}
+	return b
+}
+
+// WithUnhealthyTaints adds the given value to the UnhealthyTaints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UnhealthyTaints field.
+func (b *NodeHealthPolicyApplyConfiguration) WithUnhealthyTaints(values ...string) *NodeHealthPolicyApplyConfiguration {
+	for i := range values {
+		b.UnhealthyTaints = append(b.UnhealthyTaints, values[i])
+	}
+	return b
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
+)
+
+// NodePoolApplyConfiguration represents an declarative configuration of the NodePool type for use
+// with apply.
+type NodePoolApplyConfiguration struct {
+	Name        *string                                 `json:"name,omitempty"`
+	Mode        *v1beta1.NodePoolMode                   `json:"mode,omitempty"`
+	Taint       *bool                                   `json:"taint,omitempty"`
+	MachineType []NodePoolMachineTypeApplyConfiguration `json:"machineType,omitempty"`
+	Drain       *DrainPolicyApplyConfiguration          `json:"drain,omitempty"`
+}
+
+// NodePoolApplyConfiguration constructs an declarative configuration of the NodePool type for use with
+// apply.
+func NodePool() *NodePoolApplyConfiguration {
+	return &NodePoolApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *NodePoolApplyConfiguration) WithName(value string) *NodePoolApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *NodePoolApplyConfiguration) WithMode(value v1beta1.NodePoolMode) *NodePoolApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithTaint sets the Taint field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Taint field is set to the value of the last call.
+func (b *NodePoolApplyConfiguration) WithTaint(value bool) *NodePoolApplyConfiguration {
+	b.Taint = &value
+	return b
+}
+
+// WithMachineType adds the given value to the MachineType field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the MachineType field.
+func (b *NodePoolApplyConfiguration) WithMachineType(values ...*NodePoolMachineTypeApplyConfiguration) *NodePoolApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMachineType")
+		}
+		b.MachineType = append(b.MachineType, *values[i])
+	}
+	return b
+}
+
+// WithDrain sets the Drain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Drain field is set to the value of the last call.
+func (b *NodePoolApplyConfiguration) WithDrain(value *DrainPolicyApplyConfiguration) *NodePoolApplyConfiguration {
+	b.Drain = value
+	return b
+}
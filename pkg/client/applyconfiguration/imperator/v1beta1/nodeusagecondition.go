@@ -0,0 +1,66 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
+)
+
+// NodeUsageConditionApplyConfiguration represents an declarative configuration of the NodeUsageCondition type for use
+// with apply.
+type NodeUsageConditionApplyConfiguration struct {
+	Name         *string                                  `json:"name,omitempty"`
+	Condition    *v1beta1.MachineNodeCondition            `json:"condition,omitempty"`
+	MachineTypes []NodeMachineTypeUsageApplyConfiguration `json:"machineTypes,omitempty"`
+}
+
+// NodeUsageConditionApplyConfiguration constructs an declarative configuration of the NodeUsageCondition type for use with
+// apply.
+func NodeUsageCondition() *NodeUsageConditionApplyConfiguration {
+	return &NodeUsageConditionApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *NodeUsageConditionApplyConfiguration) WithName(value string) *NodeUsageConditionApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithCondition sets the Condition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Condition field is set to the value of the last call.
+func (b *NodeUsageConditionApplyConfiguration) WithCondition(value v1beta1.MachineNodeCondition) *NodeUsageConditionApplyConfiguration {
+	b.Condition = &value
+	return b
+}
+
+// WithMachineTypes adds the given value to the MachineTypes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the MachineTypes field.
+func (b *NodeUsageConditionApplyConfiguration) WithMachineTypes(values ...*NodeMachineTypeUsageApplyConfiguration) *NodeUsageConditionApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMachineTypes")
+		}
+		b.MachineTypes = append(b.MachineTypes, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
+	v1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
+	imperatorv1alpha1 "github.com/tenzen-y/imperator/pkg/client/applyconfiguration/imperator/v1alpha1"
+	imperatorv1beta1 "github.com/tenzen-y/imperator/pkg/client/applyconfiguration/imperator/v1beta1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=imperator.tenzen-y.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("AvailableMachineCondition"):
+		return &imperatorv1alpha1.AvailableMachineConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DrainPolicy"):
+		return &imperatorv1alpha1.DrainPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GPUSpec"):
+		return &imperatorv1alpha1.GPUSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("InjectionPolicy"):
+		return &imperatorv1alpha1.InjectionPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Machine"):
+		return &imperatorv1alpha1.MachineApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MachineDetailSpec"):
+		return &imperatorv1alpha1.MachineDetailSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MachineNodePool"):
+		return &imperatorv1alpha1.MachineNodePoolApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MachineNodePoolSpec"):
+		return &imperatorv1alpha1.MachineNodePoolSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MachineNodePoolStatus"):
+		return &imperatorv1alpha1.MachineNodePoolStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MachineQuota"):
+		return &imperatorv1alpha1.MachineQuotaApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MachineQuotaSpec"):
+		return &imperatorv1alpha1.MachineQuotaSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MachineSpec"):
+		return &imperatorv1alpha1.MachineSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MachineStatus"):
+		return &imperatorv1alpha1.MachineStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MachineType"):
+		return &imperatorv1alpha1.MachineTypeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MachineTypeQuota"):
+		return &imperatorv1alpha1.MachineTypeQuotaApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NamespaceUsageCondition"):
+		return &imperatorv1alpha1.NamespaceUsageConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeHealthPolicy"):
+		return &imperatorv1alpha1.NodeHealthPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeMachineTypeUsage"):
+		return &imperatorv1alpha1.NodeMachineTypeUsageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodePool"):
+		return &imperatorv1alpha1.NodePoolApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodePoolCondition"):
+		return &imperatorv1alpha1.NodePoolConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodePoolMachineType"):
+		return &imperatorv1alpha1.NodePoolMachineTypeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodePoolMachineTypeStock"):
+		return &imperatorv1alpha1.NodePoolMachineTypeStockApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodePoolSelector"):
+		return &imperatorv1alpha1.NodePoolSelectorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NodeUsageCondition"):
+		return &imperatorv1alpha1.NodeUsageConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UnhealthyNodeCondition"):
+		return &imperatorv1alpha1.UnhealthyNodeConditionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UsageCondition"):
+		return &imperatorv1alpha1.UsageConditionApplyConfiguration{}
+
+		// Group=imperator.tenzen-y.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithKind("AvailableMachineCondition"):
+		return &imperatorv1beta1.AvailableMachineConditionApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("DrainPolicy"):
+		return &imperatorv1beta1.DrainPolicyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("GPUSpec"):
+		return &imperatorv1beta1.GPUSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("InjectionPolicy"):
+		return &imperatorv1beta1.InjectionPolicyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("Machine"):
+		return &imperatorv1beta1.MachineApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineDetailSpec"):
+		return &imperatorv1beta1.MachineDetailSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineNodePool"):
+		return &imperatorv1beta1.MachineNodePoolApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineNodePoolSpec"):
+		return &imperatorv1beta1.MachineNodePoolSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineNodePoolStatus"):
+		return &imperatorv1beta1.MachineNodePoolStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineQuota"):
+		return &imperatorv1beta1.MachineQuotaApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineQuotaSpec"):
+		return &imperatorv1beta1.MachineQuotaSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineSpec"):
+		return &imperatorv1beta1.MachineSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineStatus"):
+		return &imperatorv1beta1.MachineStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineType"):
+		return &imperatorv1beta1.MachineTypeApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineTypeQuota"):
+		return &imperatorv1beta1.MachineTypeQuotaApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NamespaceUsageCondition"):
+		return &imperatorv1beta1.NamespaceUsageConditionApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodeHealthPolicy"):
+		return &imperatorv1beta1.NodeHealthPolicyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodeMachineTypeUsage"):
+		return &imperatorv1beta1.NodeMachineTypeUsageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePool"):
+		return &imperatorv1beta1.NodePoolApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePoolCondition"):
+		return &imperatorv1beta1.NodePoolConditionApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePoolMachineType"):
+		return &imperatorv1beta1.NodePoolMachineTypeApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePoolSelector"):
+		return &imperatorv1beta1.NodePoolSelectorApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodeUsageCondition"):
+		return &imperatorv1beta1.NodeUsageConditionApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("UnhealthyNodeCondition"):
+		return &imperatorv1beta1.UnhealthyNodeConditionApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("UsageCondition"):
+		return &imperatorv1beta1.UsageConditionApplyConfiguration{}
+
+	}
+	return nil
+}
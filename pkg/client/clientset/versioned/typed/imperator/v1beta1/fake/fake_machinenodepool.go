@@ -0,0 +1,179 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
+	imperatorv1beta1 "github.com/tenzen-y/imperator/pkg/client/applyconfiguration/imperator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeMachineNodePools implements MachineNodePoolInterface
+type FakeMachineNodePools struct {
+	Fake *FakeImperatorV1beta1
+}
+
+var machinenodepoolsResource = schema.GroupVersionResource{Group: "imperator.tenzen-y.io", Version: "v1beta1", Resource: "machinenodepools"}
+
+var machinenodepoolsKind = schema.GroupVersionKind{Group: "imperator.tenzen-y.io", Version: "v1beta1", Kind: "MachineNodePool"}
+
+// Get takes name of the machineNodePool, and returns the corresponding machineNodePool object, and an error if there is any.
+func (c *FakeMachineNodePools) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.MachineNodePool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(machinenodepoolsResource, name), &v1beta1.MachineNodePool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineNodePool), err
+}
+
+// List takes label and field selectors, and returns the list of MachineNodePools that match those selectors.
+func (c *FakeMachineNodePools) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.MachineNodePoolList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(machinenodepoolsResource, machinenodepoolsKind, opts), &v1beta1.MachineNodePoolList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.MachineNodePoolList{ListMeta: obj.(*v1beta1.MachineNodePoolList).ListMeta}
+	for _, item := range obj.(*v1beta1.MachineNodePoolList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested machineNodePools.
+func (c *FakeMachineNodePools) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(machinenodepoolsResource, opts))
+}
+
+// Create takes the representation of a machineNodePool and creates it.  Returns the server's representation of the machineNodePool, and an error, if there is any.
+func (c *FakeMachineNodePools) Create(ctx context.Context, machineNodePool *v1beta1.MachineNodePool, opts v1.CreateOptions) (result *v1beta1.MachineNodePool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(machinenodepoolsResource, machineNodePool), &v1beta1.MachineNodePool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineNodePool), err
+}
+
+// Update takes the representation of a machineNodePool and updates it. Returns the server's representation of the machineNodePool, and an error, if there is any.
+func (c *FakeMachineNodePools) Update(ctx context.Context, machineNodePool *v1beta1.MachineNodePool, opts v1.UpdateOptions) (result *v1beta1.MachineNodePool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(machinenodepoolsResource, machineNodePool), &v1beta1.MachineNodePool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineNodePool), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeMachineNodePools) UpdateStatus(ctx context.Context, machineNodePool *v1beta1.MachineNodePool, opts v1.UpdateOptions) (*v1beta1.MachineNodePool, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(machinenodepoolsResource, "status", machineNodePool), &v1beta1.MachineNodePool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineNodePool), err
+}
+
+// Delete takes name of the machineNodePool and deletes it. Returns an error if one occurs.
+func (c *FakeMachineNodePools) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(machinenodepoolsResource, name), &v1beta1.MachineNodePool{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeMachineNodePools) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(machinenodepoolsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.MachineNodePoolList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched machineNodePool.
+func (c *FakeMachineNodePools) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.MachineNodePool, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(machinenodepoolsResource, name, pt, data, subresources...), &v1beta1.MachineNodePool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineNodePool), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied machineNodePool.
+func (c *FakeMachineNodePools) Apply(ctx context.Context, machineNodePool *imperatorv1beta1.MachineNodePoolApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.MachineNodePool, err error) {
+	if machineNodePool == nil {
+		return nil, fmt.Errorf("machineNodePool provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(machineNodePool)
+	if err != nil {
+		return nil, err
+	}
+	name := machineNodePool.Name
+	if name == nil {
+		return nil, fmt.Errorf("machineNodePool.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(machinenodepoolsResource, *name, types.ApplyPatchType, data), &v1beta1.MachineNodePool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineNodePool), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeMachineNodePools) ApplyStatus(ctx context.Context, machineNodePool *imperatorv1beta1.MachineNodePoolApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.MachineNodePool, err error) {
+	if machineNodePool == nil {
+		return nil, fmt.Errorf("machineNodePool provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(machineNodePool)
+	if err != nil {
+		return nil, err
+	}
+	name := machineNodePool.Name
+	if name == nil {
+		return nil, fmt.Errorf("machineNodePool.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(machinenodepoolsResource, *name, types.ApplyPatchType, data, "status"), &v1beta1.MachineNodePool{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineNodePool), err
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
+	imperatorv1beta1 "github.com/tenzen-y/imperator/pkg/client/applyconfiguration/imperator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeMachineQuotas implements MachineQuotaInterface
+type FakeMachineQuotas struct {
+	Fake *FakeImperatorV1beta1
+	ns   string
+}
+
+var machinequotasResource = schema.GroupVersionResource{Group: "imperator.tenzen-y.io", Version: "v1beta1", Resource: "machinequotas"}
+
+var machinequotasKind = schema.GroupVersionKind{Group: "imperator.tenzen-y.io", Version: "v1beta1", Kind: "MachineQuota"}
+
+// Get takes name of the machineQuota, and returns the corresponding machineQuota object, and an error if there is any.
+func (c *FakeMachineQuotas) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.MachineQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(machinequotasResource, c.ns, name), &v1beta1.MachineQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineQuota), err
+}
+
+// List takes label and field selectors, and returns the list of MachineQuotas that match those selectors.
+func (c *FakeMachineQuotas) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.MachineQuotaList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(machinequotasResource, machinequotasKind, c.ns, opts), &v1beta1.MachineQuotaList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.MachineQuotaList{ListMeta: obj.(*v1beta1.MachineQuotaList).ListMeta}
+	for _, item := range obj.(*v1beta1.MachineQuotaList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested machineQuotas.
+func (c *FakeMachineQuotas) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(machinequotasResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a machineQuota and creates it.  Returns the server's representation of the machineQuota, and an error, if there is any.
+func (c *FakeMachineQuotas) Create(ctx context.Context, machineQuota *v1beta1.MachineQuota, opts v1.CreateOptions) (result *v1beta1.MachineQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(machinequotasResource, c.ns, machineQuota), &v1beta1.MachineQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineQuota), err
+}
+
+// Update takes the representation of a machineQuota and updates it. Returns the server's representation of the machineQuota, and an error, if there is any.
+func (c *FakeMachineQuotas) Update(ctx context.Context, machineQuota *v1beta1.MachineQuota, opts v1.UpdateOptions) (result *v1beta1.MachineQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(machinequotasResource, c.ns, machineQuota), &v1beta1.MachineQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineQuota), err
+}
+
+// Delete takes name of the machineQuota and deletes it. Returns an error if one occurs.
+func (c *FakeMachineQuotas) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(machinequotasResource, c.ns, name), &v1beta1.MachineQuota{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeMachineQuotas) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(machinequotasResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.MachineQuotaList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched machineQuota.
+func (c *FakeMachineQuotas) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.MachineQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(machinequotasResource, c.ns, name, pt, data, subresources...), &v1beta1.MachineQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineQuota), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied machineQuota.
+func (c *FakeMachineQuotas) Apply(ctx context.Context, machineQuota *imperatorv1beta1.MachineQuotaApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.MachineQuota, err error) {
+	if machineQuota == nil {
+		return nil, fmt.Errorf("machineQuota provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(machineQuota)
+	if err != nil {
+		return nil, err
+	}
+	name := machineQuota.Name
+	if name == nil {
+		return nil, fmt.Errorf("machineQuota.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(machinequotasResource, c.ns, *name, types.ApplyPatchType, data), &v1beta1.MachineQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.MachineQuota), err
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
+	imperatorv1beta1 "github.com/tenzen-y/imperator/pkg/client/applyconfiguration/imperator/v1beta1"
+	scheme "github.com/tenzen-y/imperator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// MachineNodePoolsGetter has a method to return a MachineNodePoolInterface.
+// A group's client should implement this interface.
+type MachineNodePoolsGetter interface {
+	MachineNodePools() MachineNodePoolInterface
+}
+
+// MachineNodePoolInterface has methods to work with MachineNodePool resources.
+type MachineNodePoolInterface interface {
+	Create(ctx context.Context, machineNodePool *v1beta1.MachineNodePool, opts v1.CreateOptions) (*v1beta1.MachineNodePool, error)
+	Update(ctx context.Context, machineNodePool *v1beta1.MachineNodePool, opts v1.UpdateOptions) (*v1beta1.MachineNodePool, error)
+	UpdateStatus(ctx context.Context, machineNodePool *v1beta1.MachineNodePool, opts v1.UpdateOptions) (*v1beta1.MachineNodePool, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.MachineNodePool, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.MachineNodePoolList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.MachineNodePool, err error)
+	Apply(ctx context.Context, machineNodePool *imperatorv1beta1.MachineNodePoolApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.MachineNodePool, err error)
+	ApplyStatus(ctx context.Context, machineNodePool *imperatorv1beta1.MachineNodePoolApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.MachineNodePool, err error)
+	MachineNodePoolExpansion
+}
+
+// machineNodePools implements MachineNodePoolInterface
+type machineNodePools struct {
+	client rest.Interface
+}
+
+// newMachineNodePools returns a MachineNodePools
+func newMachineNodePools(c *ImperatorV1beta1Client) *machineNodePools {
+	return &machineNodePools{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the machineNodePool, and returns the corresponding machineNodePool object, and an error if there is any.
+func (c *machineNodePools) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.MachineNodePool, err error) {
+	result = &v1beta1.MachineNodePool{}
+	err = c.client.Get().
+		Resource("machinenodepools").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of MachineNodePools that match those selectors.
+func (c *machineNodePools) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.MachineNodePoolList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.MachineNodePoolList{}
+	err = c.client.Get().
+		Resource("machinenodepools").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested machineNodePools.
+func (c *machineNodePools) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("machinenodepools").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a machineNodePool and creates it.  Returns the server's representation of the machineNodePool, and an error, if there is any.
+func (c *machineNodePools) Create(ctx context.Context, machineNodePool *v1beta1.MachineNodePool, opts v1.CreateOptions) (result *v1beta1.MachineNodePool, err error) {
+	result = &v1beta1.MachineNodePool{}
+	err = c.client.Post().
+		Resource("machinenodepools").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(machineNodePool).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a machineNodePool and updates it. Returns the server's representation of the machineNodePool, and an error, if there is any.
+func (c *machineNodePools) Update(ctx context.Context, machineNodePool *v1beta1.MachineNodePool, opts v1.UpdateOptions) (result *v1beta1.MachineNodePool, err error) {
+	result = &v1beta1.MachineNodePool{}
+	err = c.client.Put().
+		Resource("machinenodepools").
+		Name(machineNodePool.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(machineNodePool).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *machineNodePools) UpdateStatus(ctx context.Context, machineNodePool *v1beta1.MachineNodePool, opts v1.UpdateOptions) (result *v1beta1.MachineNodePool, err error) {
+	result = &v1beta1.MachineNodePool{}
+	err = c.client.Put().
+		Resource("machinenodepools").
+		Name(machineNodePool.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(machineNodePool).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the machineNodePool and deletes it. Returns an error if one occurs.
+func (c *machineNodePools) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("machinenodepools").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *machineNodePools) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("machinenodepools").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched machineNodePool.
+func (c *machineNodePools) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.MachineNodePool, err error) {
+	result = &v1beta1.MachineNodePool{}
+	err = c.client.Patch(pt).
+		Resource("machinenodepools").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied machineNodePool.
+func (c *machineNodePools) Apply(ctx context.Context, machineNodePool *imperatorv1beta1.MachineNodePoolApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.MachineNodePool, err error) {
+	if machineNodePool == nil {
+		return nil, fmt.Errorf("machineNodePool provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(machineNodePool)
+	if err != nil {
+		return nil, err
+	}
+	name := machineNodePool.Name
+	if name == nil {
+		return nil, fmt.Errorf("machineNodePool.Name must be provided to Apply")
+	}
+	result = &v1beta1.MachineNodePool{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("machinenodepools").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *machineNodePools) ApplyStatus(ctx context.Context, machineNodePool *imperatorv1beta1.MachineNodePoolApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.MachineNodePool, err error) {
+	if machineNodePool == nil {
+		return nil, fmt.Errorf("machineNodePool provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(machineNodePool)
+	if err != nil {
+		return nil, err
+	}
+
+	name := machineNodePool.Name
+	if name == nil {
+		return nil, fmt.Errorf("machineNodePool.Name must be provided to Apply")
+	}
+
+	result = &v1beta1.MachineNodePool{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("machinenodepools").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
+	imperatorv1beta1 "github.com/tenzen-y/imperator/pkg/client/applyconfiguration/imperator/v1beta1"
+	scheme "github.com/tenzen-y/imperator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// MachineQuotasGetter has a method to return a MachineQuotaInterface.
+// A group's client should implement this interface.
+type MachineQuotasGetter interface {
+	MachineQuotas(namespace string) MachineQuotaInterface
+}
+
+// MachineQuotaInterface has methods to work with MachineQuota resources.
+type MachineQuotaInterface interface {
+	Create(ctx context.Context, machineQuota *v1beta1.MachineQuota, opts v1.CreateOptions) (*v1beta1.MachineQuota, error)
+	Update(ctx context.Context, machineQuota *v1beta1.MachineQuota, opts v1.UpdateOptions) (*v1beta1.MachineQuota, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.MachineQuota, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.MachineQuotaList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.MachineQuota, err error)
+	Apply(ctx context.Context, machineQuota *imperatorv1beta1.MachineQuotaApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.MachineQuota, err error)
+	MachineQuotaExpansion
+}
+
+// machineQuotas implements MachineQuotaInterface
+type machineQuotas struct {
+	client rest.Interface
+	ns     string
+}
+
+// newMachineQuotas returns a MachineQuotas
+func newMachineQuotas(c *ImperatorV1beta1Client, namespace string) *machineQuotas {
+	return &machineQuotas{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the machineQuota, and returns the corresponding machineQuota object, and an error if there is any.
+func (c *machineQuotas) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.MachineQuota, err error) {
+	result = &v1beta1.MachineQuota{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("machinequotas").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of MachineQuotas that match those selectors.
+func (c *machineQuotas) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.MachineQuotaList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.MachineQuotaList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("machinequotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested machineQuotas.
+func (c *machineQuotas) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("machinequotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a machineQuota and creates it.  Returns the server's representation of the machineQuota, and an error, if there is any.
+func (c *machineQuotas) Create(ctx context.Context, machineQuota *v1beta1.MachineQuota, opts v1.CreateOptions) (result *v1beta1.MachineQuota, err error) {
+	result = &v1beta1.MachineQuota{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("machinequotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(machineQuota).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a machineQuota and updates it. Returns the server's representation of the machineQuota, and an error, if there is any.
+func (c *machineQuotas) Update(ctx context.Context, machineQuota *v1beta1.MachineQuota, opts v1.UpdateOptions) (result *v1beta1.MachineQuota, err error) {
+	result = &v1beta1.MachineQuota{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("machinequotas").
+		Name(machineQuota.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(machineQuota).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the machineQuota and deletes it. Returns an error if one occurs.
+func (c *machineQuotas) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("machinequotas").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *machineQuotas) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("machinequotas").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched machineQuota.
+func (c *machineQuotas) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.MachineQuota, err error) {
+	result = &v1beta1.MachineQuota{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("machinequotas").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied machineQuota.
+func (c *machineQuotas) Apply(ctx context.Context, machineQuota *imperatorv1beta1.MachineQuotaApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.MachineQuota, err error) {
+	if machineQuota == nil {
+		return nil, fmt.Errorf("machineQuota provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(machineQuota)
+	if err != nil {
+		return nil, err
+	}
+	name := machineQuota.Name
+	if name == nil {
+		return nil, fmt.Errorf("machineQuota.Name must be provided to Apply")
+	}
+	result = &v1beta1.MachineQuota{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("machinequotas").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	internalinterfaces "github.com/tenzen-y/imperator/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// Machines returns a MachineInformer.
+	Machines() MachineInformer
+	// MachineNodePools returns a MachineNodePoolInformer.
+	MachineNodePools() MachineNodePoolInformer
+	// MachineQuotas returns a MachineQuotaInformer.
+	MachineQuotas() MachineQuotaInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// Machines returns a MachineInformer.
+func (v *version) Machines() MachineInformer {
+	return &machineInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// MachineNodePools returns a MachineNodePoolInformer.
+func (v *version) MachineNodePools() MachineNodePoolInformer {
+	return &machineNodePoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// MachineQuotas returns a MachineQuotaInformer.
+func (v *version) MachineQuotas() MachineQuotaInformer {
+	return &machineQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	time "time"
+
+	imperatorv1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
+	versioned "github.com/tenzen-y/imperator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/tenzen-y/imperator/pkg/client/informers/externalversions/internalinterfaces"
+	v1beta1 "github.com/tenzen-y/imperator/pkg/client/listers/imperator/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// MachineNodePoolInformer provides access to a shared informer and lister for
+// MachineNodePools.
+type MachineNodePoolInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1beta1.MachineNodePoolLister
+}
+
+type machineNodePoolInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewMachineNodePoolInformer constructs a new informer for MachineNodePool type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewMachineNodePoolInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredMachineNodePoolInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredMachineNodePoolInformer constructs a new informer for MachineNodePool type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredMachineNodePoolInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ImperatorV1beta1().MachineNodePools().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ImperatorV1beta1().MachineNodePools().Watch(context.TODO(), options)
+			},
+		},
+		&imperatorv1beta1.MachineNodePool{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *machineNodePoolInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredMachineNodePoolInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *machineNodePoolInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&imperatorv1beta1.MachineNodePool{}, f.defaultInformer)
+}
+
+func (f *machineNodePoolInformer) Lister() v1beta1.MachineNodePoolLister {
+	return v1beta1.NewMachineNodePoolLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// MachineNodePoolLister helps list MachineNodePools.
+// All objects returned here must be treated as read-only.
+type MachineNodePoolLister interface {
+	// List lists all MachineNodePools in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.MachineNodePool, err error)
+	// Get retrieves the MachineNodePool from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.MachineNodePool, error)
+	MachineNodePoolListerExpansion
+}
+
+// machineNodePoolLister implements the MachineNodePoolLister interface.
+type machineNodePoolLister struct {
+	indexer cache.Indexer
+}
+
+// NewMachineNodePoolLister returns a new MachineNodePoolLister.
+func NewMachineNodePoolLister(indexer cache.Indexer) MachineNodePoolLister {
+	return &machineNodePoolLister{indexer: indexer}
+}
+
+// List lists all MachineNodePools in the indexer.
+func (s *machineNodePoolLister) List(selector labels.Selector) (ret []*v1alpha1.MachineNodePool, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.MachineNodePool))
+	})
+	return ret, err
+}
+
+// Get retrieves the MachineNodePool from the index for a given name.
+func (s *machineNodePoolLister) Get(name string) (*v1alpha1.MachineNodePool, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("machinenodepool"), name)
+	}
+	return obj.(*v1alpha1.MachineNodePool), nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/tenzen-y/imperator/pkg/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// MachineQuotaLister helps list MachineQuotas.
+// All objects returned here must be treated as read-only.
+type MachineQuotaLister interface {
+	// List lists all MachineQuotas in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.MachineQuota, err error)
+	// MachineQuotas returns an object that can list and get MachineQuotas.
+	MachineQuotas(namespace string) MachineQuotaNamespaceLister
+	MachineQuotaListerExpansion
+}
+
+// machineQuotaLister implements the MachineQuotaLister interface.
+type machineQuotaLister struct {
+	indexer cache.Indexer
+}
+
+// NewMachineQuotaLister returns a new MachineQuotaLister.
+func NewMachineQuotaLister(indexer cache.Indexer) MachineQuotaLister {
+	return &machineQuotaLister{indexer: indexer}
+}
+
+// List lists all MachineQuotas in the indexer.
+func (s *machineQuotaLister) List(selector labels.Selector) (ret []*v1beta1.MachineQuota, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.MachineQuota))
+	})
+	return ret, err
+}
+
+// MachineQuotas returns an object that can list and get MachineQuotas.
+func (s *machineQuotaLister) MachineQuotas(namespace string) MachineQuotaNamespaceLister {
+	return machineQuotaNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// MachineQuotaNamespaceLister helps list and get MachineQuotas.
+// All objects returned here must be treated as read-only.
+type MachineQuotaNamespaceLister interface {
+	// List lists all MachineQuotas in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.MachineQuota, err error)
+	// Get retrieves the MachineQuota from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.MachineQuota, error)
+	MachineQuotaNamespaceListerExpansion
+}
+
+// machineQuotaNamespaceLister implements the MachineQuotaNamespaceLister
+// interface.
+type machineQuotaNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all MachineQuotas in the indexer for a given namespace.
+func (s machineQuotaNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.MachineQuota, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.MachineQuota))
+	})
+	return ret, err
+}
+
+// Get retrieves the MachineQuota from the indexer for a given namespace and name.
+func (s machineQuotaNamespaceLister) Get(name string) (*v1beta1.MachineQuota, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("machinequota"), name)
+	}
+	return obj.(*v1beta1.MachineQuota), nil
+}
@@ -30,6 +30,9 @@ const (
 	MachineTypeKey   = "imperator.tenzen-y.io/machine-type"
 	PodRoleKey       = "imperator.tenzen-y.io/pod-role"
 
+	// MachineTypeStockKey keeps machineTypeStock of v1alpha1 MachineNodePool which can not be derived from nodePool and nodeSelector.
+	MachineTypeStockKey = "imperator.tenzen-y.io/machine-type-stock"
+
 	StatefulSetImage = "alpine:3.15.0"
 
 	NvidiaGPUFamilyKey  = "nvidia.com/gpu.family"
@@ -42,25 +45,42 @@ const (
 	PodRoleGuest        = "guest"
 
 	MachineNodePoolFinalizer = "imperator-machinenodepool-finalizer"
+	FieldManager             = "imperator"
 	NodeNotReadyTaint        = "node.kubernetes.io/not-ready"
 	SuiteTestTimeOut         = time.Second * 5
+	DrainRequeueInterval     = time.Second * 5
 
 	ImperatorResourceInjectionKey     = "imperator.tenzen.io/inject-resource"
 	ImperatorResourceInjectionEnabled = "enabled"
 
 	ImperatorResourceInjectContainerNameKey = "imperator.tenzen-y.io/injecting-container"
+	ImperatorContainerResourceRatioKey      = "imperator.tenzen-y.io/container-resource-ratio"
+	ImperatorSubtractSidecarResourcesKey    = "imperator.tenzen-y.io/subtract-sidecar-resources"
+	ImperatorInjectedContainerNameKey       = "imperator.tenzen-y.io/injected-container"
 	PodResourceInjectorPath                 = "/mutate-core-v1-pod"
+	WorkloadResourceInjectorPath            = "/mutate-workload"
+	ReservationValidatorPath                = "/validate-reservation"
+	MachineValidatorPath                    = "/validate-imperator-tenzen-y-io-v1alpha1-machine"
+	MachineUseVerb                          = "use"
+	PodNodeNameField                        = "spec.nodeName"
+	MachineGroupField                       = "metadata.labels.machineGroup"
+
+	ReservationPriorityClassName = "imperator-reservation"
+	ReservationPriority          = -100
+	GuestPriorityClassName       = "imperator-guest"
+	GuestPriority                = 0
 )
 
 var (
-	CannotUseNodeTaints = []string{
-		"node.kubernetes.io/not-ready",
+	// DefaultUnhealthyNodeTaints is taint keys to treat nodes as not-ready.
+	// node.kubernetes.io/not-ready and node.kubernetes.io/unreachable are covered by Ready condition.
+	DefaultUnhealthyNodeTaints = []string{
 		"node.kubernetes.io/unschedulable",
 		"node.kubernetes.io/network-unavailable",
-		"node.kubernetes.io/unreachable",
 	}
-	ImperatorCoreNamespace = getEnvVarOrDefault("IMPERATOR_CORE_NAMESPACE", "imperator-system")
-	CmpSliceOpts           = []cmp.Option{
+	ImperatorCoreNamespace  = getEnvVarOrDefault("IMPERATOR_CORE_NAMESPACE", "imperator-system")
+	ImperatorServiceAccount = getEnvVarOrDefault("IMPERATOR_SERVICE_ACCOUNT", "imperator-controller")
+	CmpSliceOpts            = []cmp.Option{
 		cmpopts.SortSlices(func(i, j int) bool {
 			return i < j
 		}),
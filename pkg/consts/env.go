@@ -16,7 +16,10 @@ limitations under the License.
 
 package consts
 
-import "os"
+import (
+	"fmt"
+	"os"
+)
 
 func getEnvVarOrDefault(key, fallback string) string {
 	if value, exist := os.LookupEnv(key); exist {
@@ -24,3 +27,8 @@ func getEnvVarOrDefault(key, fallback string) string {
 	}
 	return fallback
 }
+
+// ImperatorServiceAccountUsername returns the username of imperator in admission requests.
+func ImperatorServiceAccountUsername() string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", ImperatorCoreNamespace, ImperatorServiceAccount)
+}
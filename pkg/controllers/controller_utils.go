@@ -2,16 +2,22 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"math"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	imperatorv1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
+	"github.com/tenzen-y/imperator/pkg/consts"
 	"github.com/tenzen-y/imperator/pkg/controllers/util"
 )
 
@@ -92,3 +98,375 @@ func (r *MachineReconciler) updateReconcileConditions(ctx context.Context, opeRe
 	}
 	return nil
 }
+
+// getSharedMachineTypeCapacity returns how many units of each machineType can be placed
+// for machineTypes which share Nodes with other machineTypes.
+// machineTypes which do not share any Nodes are not included in the returned map.
+func (r *MachineReconciler) getSharedMachineTypeCapacity(ctx context.Context, machine *imperatorv1alpha1.Machine,
+	nodePool []imperatorv1alpha1.NodePool) (map[string]int32, error) {
+	machineGroup := util.GetMachineGroup(machine.Labels)
+
+	nodeMachineTypes := make(map[string][]string)
+	sharedMachineTypes := make(map[string]bool)
+	for _, np := range nodePool {
+		if np.Mode == imperatorv1alpha1.NodeModeMaintenance {
+			continue
+		}
+		for _, npmt := range np.MachineType {
+			nodeMachineTypes[np.Name] = append(nodeMachineTypes[np.Name], npmt.Name)
+		}
+		if len(np.MachineType) > 1 {
+			for _, npmt := range np.MachineType {
+				sharedMachineTypes[npmt.Name] = true
+			}
+		}
+	}
+	if len(sharedMachineTypes) == 0 {
+		return map[string]int32{}, nil
+	}
+
+	var machineTypes []imperatorv1alpha1.MachineType
+	for _, mt := range machine.Spec.MachineTypes {
+		if sharedMachineTypes[mt.Name] {
+			// the number of units for autoCapacity is bounded by usage.maximum in reconcileStatefulSet.
+			if mt.AutoCapacity {
+				mt.Available = math.MaxInt32
+			}
+			machineTypes = append(machineTypes, mt)
+		}
+	}
+
+	for nodeName, mtNames := range nodeMachineTypes {
+		isShared := false
+		for _, mtName := range mtNames {
+			if sharedMachineTypes[mtName] {
+				isShared = true
+				break
+			}
+		}
+		if !isShared {
+			delete(nodeMachineTypes, nodeName)
+		}
+	}
+
+	nodes, nodePods, err := r.getNodesAndPods(ctx, nodeMachineTypes)
+	if err != nil {
+		return nil, err
+	}
+	placed := make(map[string]int32)
+	nodeFree := make(map[string]corev1.ResourceList)
+	for nodeName := range nodeMachineTypes {
+		node, exist := nodes[nodeName]
+		if !exist {
+			delete(nodeMachineTypes, nodeName)
+			continue
+		}
+		free := node.Status.Allocatable.DeepCopy()
+		if free == nil {
+			free = corev1.ResourceList{}
+		}
+		for _, po := range nodePods[nodeName] {
+			// Pods for this machineGroup occupy a unit of machineType.
+			if po.Labels[consts.MachineGroupKey] == machineGroup && sharedMachineTypes[po.Labels[consts.MachineTypeKey]] {
+				placed[po.Labels[consts.MachineTypeKey]]++
+			}
+			for name, q := range util.GetPodResourceRequests(&po) {
+				f, exist := free[name]
+				if !exist {
+					continue
+				}
+				f.Sub(q)
+				free[name] = f
+			}
+		}
+		nodeFree[nodeName] = free
+	}
+
+	return imperatorv1alpha1.EstimateMachineTypeCapacity(machineTypes, placed, nodeFree, nodeMachineTypes), nil
+}
+
+// getAutoMachineTypeCapacity returns how many units of each machineType with autoCapacity fit
+// on healthy Nodes in ready mode.
+// Free resources of a Node are allocatable resources minus resources requested by DaemonSet Pods,
+// since DaemonSet Pods run on every Node regardless of machineTypes.
+// machineTypes without autoCapacity are not included in the returned map.
+func (r *MachineReconciler) getAutoMachineTypeCapacity(ctx context.Context, machine *imperatorv1alpha1.Machine) (map[string]int32, error) {
+	var machineTypes []imperatorv1alpha1.MachineType
+	for _, mt := range machine.Spec.MachineTypes {
+		if !mt.AutoCapacity {
+			continue
+		}
+		// place units until Nodes are full.
+		mt.Available = math.MaxInt32
+		machineTypes = append(machineTypes, mt)
+	}
+	if len(machineTypes) == 0 {
+		return map[string]int32{}, nil
+	}
+
+	nodePool, err := r.getNodePool(ctx, machine)
+	if err != nil {
+		return nil, err
+	}
+
+	// node health is evaluated by the MachineNodePool controller.
+	pool := &imperatorv1alpha1.MachineNodePool{}
+	if err = r.Get(ctx, client.ObjectKey{Name: util.GenerateMachineNodePoolName(util.GetMachineGroup(machine.Labels))}, pool); errors.IsNotFound(err) {
+		return map[string]int32{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	healthyNodes := make(map[string]bool)
+	for _, npc := range pool.Status.NodePoolCondition {
+		if npc.NodeCondition == imperatorv1alpha1.NodeHealthy {
+			healthyNodes[npc.Name] = true
+		}
+	}
+
+	nodeMachineTypes := make(map[string][]string)
+	for _, np := range nodePool {
+		if np.Mode != imperatorv1alpha1.NodeModeReady || !healthyNodes[np.Name] {
+			continue
+		}
+		for _, npmt := range np.MachineType {
+			nodeMachineTypes[np.Name] = append(nodeMachineTypes[np.Name], npmt.Name)
+		}
+	}
+
+	nodes, nodePods, err := r.getNodesAndPods(ctx, nodeMachineTypes)
+	if err != nil {
+		return nil, err
+	}
+	nodeFree := make(map[string]corev1.ResourceList)
+	for nodeName := range nodeMachineTypes {
+		node, exist := nodes[nodeName]
+		if !exist {
+			delete(nodeMachineTypes, nodeName)
+			continue
+		}
+		free := node.Status.Allocatable.DeepCopy()
+		if free == nil {
+			free = corev1.ResourceList{}
+		}
+		for _, po := range nodePods[nodeName] {
+			if !util.IsDaemonSetPod(&po) {
+				continue
+			}
+			for name, q := range util.GetPodResourceRequests(&po) {
+				f, exist := free[name]
+				if !exist {
+					continue
+				}
+				f.Sub(q)
+				free[name] = f
+			}
+		}
+		nodeFree[nodeName] = free
+	}
+
+	return imperatorv1alpha1.EstimateMachineTypeCapacity(machineTypes, map[string]int32{}, nodeFree, nodeMachineTypes), nil
+}
+
+// getNodesAndPods returns Nodes in nodeMachineTypes and Pods which are not terminated on those Nodes.
+// Nodes are read in a single request, and Pods are read with the index for spec.nodeName,
+// so that Pods on other Nodes are not read.
+func (r *MachineReconciler) getNodesAndPods(ctx context.Context, nodeMachineTypes map[string][]string) (map[string]*corev1.Node, map[string][]corev1.Pod, error) {
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList, &client.ListOptions{}); err != nil {
+		return nil, nil, err
+	}
+
+	nodes := make(map[string]*corev1.Node, len(nodeMachineTypes))
+	nodePods := make(map[string][]corev1.Pod, len(nodeMachineTypes))
+	for idx := range nodeList.Items {
+		node := &nodeList.Items[idx]
+		if _, exist := nodeMachineTypes[node.Name]; !exist {
+			continue
+		}
+		nodes[node.Name] = node
+
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.MatchingFields{consts.PodNodeNameField: node.Name}); err != nil {
+			return nil, nil, err
+		}
+		for _, po := range pods.Items {
+			if po.Status.Phase == corev1.PodSucceeded || po.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			nodePods[node.Name] = append(nodePods[node.Name], po)
+		}
+	}
+	return nodes, nodePods, nil
+}
+
+// getNodeUsage returns the number of reservation Pods and Guest Pods on each Node with conditions of Nodes in MachineNodePool.
+func (r *MachineReconciler) getNodeUsage(ctx context.Context, machine *imperatorv1alpha1.Machine,
+	reserved, used map[string]map[string]int32) ([]imperatorv1alpha1.NodeUsageCondition, error) {
+	nodePool, err := r.getNodePool(ctx, machine)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeConditions := make(map[string]imperatorv1alpha1.MachineNodeCondition)
+	pool := &imperatorv1alpha1.MachineNodePool{}
+	if err = r.Get(ctx, client.ObjectKey{Name: util.GenerateMachineNodePoolName(util.GetMachineGroup(machine.Labels))}, pool); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	for _, npc := range pool.Status.NodePoolCondition {
+		nodeConditions[npc.Name] = npc.NodeCondition
+	}
+
+	return util.GenerateNodeUsage(nodePool, nodeConditions, reserved, used), nil
+}
+
+// getNodePool returns nodePool of the Machine expanded with Nodes matching nodeSelector.
+func (r *MachineReconciler) getNodePool(ctx context.Context, machine *imperatorv1alpha1.Machine) ([]imperatorv1alpha1.NodePool, error) {
+	if len(machine.Spec.NodeSelector) == 0 {
+		return machine.Spec.NodePool, nil
+	}
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, &client.ListOptions{}); err != nil {
+		return nil, err
+	}
+	return imperatorv1alpha1.ResolveNodePool(machine.Spec.NodePool, machine.Spec.NodeSelector, nodes.Items)
+}
+
+// getActiveMachineTypes returns machineType names which are in .spec.machineTypes and served by any nodePool.
+func getActiveMachineTypes(machine *imperatorv1alpha1.Machine, nodePool []imperatorv1alpha1.NodePool) map[string]bool {
+	nodePoolMachineTypeMap := make(map[string]bool)
+	for _, np := range nodePool {
+		for _, npmt := range np.MachineType {
+			nodePoolMachineTypeMap[npmt.Name] = true
+		}
+	}
+
+	result := make(map[string]bool)
+	for _, mt := range machine.Spec.MachineTypes {
+		if nodePoolMachineTypeMap[mt.Name] {
+			result[mt.Name] = true
+		}
+	}
+	return result
+}
+
+// pruneReservationResources deletes reservation StatefulSets and Services owned by the Machine
+// for machineTypes which were removed from .spec.machineTypes or from every nodePool.
+func (r *MachineReconciler) pruneReservationResources(ctx context.Context, machine *imperatorv1alpha1.Machine) error {
+	logger := log.FromContext(ctx)
+	machineGroup := util.GetMachineGroup(machine.Labels)
+
+	nodePool, err := r.getNodePool(ctx, machine)
+	if err != nil {
+		return err
+	}
+	activeMachineTypes := getActiveMachineTypes(machine, nodePool)
+
+	listOpts := &client.ListOptions{
+		Namespace: consts.ImperatorCoreNamespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			consts.MachineGroupKey: machineGroup,
+			consts.PodRoleKey:      consts.PodRoleReservation,
+		}),
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err = r.List(ctx, statefulSets, listOpts); err != nil {
+		return err
+	}
+	services := &corev1.ServiceList{}
+	if err = r.List(ctx, services, listOpts); err != nil {
+		return err
+	}
+
+	type reservationResource struct {
+		kind string
+		obj  client.Object
+	}
+	var resources []reservationResource
+	for idx := range statefulSets.Items {
+		resources = append(resources, reservationResource{kind: "StatefulSet", obj: &statefulSets.Items[idx]})
+	}
+	for idx := range services.Items {
+		resources = append(resources, reservationResource{kind: "Service", obj: &services.Items[idx]})
+	}
+
+	for _, res := range resources {
+		machineTypeName := res.obj.GetLabels()[consts.MachineTypeKey]
+		if activeMachineTypes[machineTypeName] || !metav1.IsControlledBy(res.obj, machine) {
+			continue
+		}
+		if err = r.Delete(ctx, res.obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %s, %s; %v", res.kind, res.obj.GetName(), err)
+		}
+		logger.Info(fmt.Sprintf("deleted %s for removed machineType, %s", res.kind, machineTypeName))
+		r.Recorder.Eventf(machine, corev1.EventTypeNormal, "Pruned", "deleted %s <%s> for removed machineType <%s>",
+			res.kind, res.obj.GetName(), machineTypeName)
+	}
+
+	return nil
+}
+
+// machineGroupIndexer indexes objects by the machineGroup label.
+func machineGroupIndexer(o client.Object) []string {
+	machineGroup, exist := o.GetLabels()[consts.MachineGroupKey]
+	if !exist {
+		return nil
+	}
+	return []string{machineGroup}
+}
+
+// machineTypePods is Pods for a machineType classified by role.
+type machineTypePods struct {
+	reservation []corev1.Pod
+	guest       []corev1.Pod
+}
+
+// getMachineGroupPods fetches all Pods for the machineGroup using the field index and groups them by machineType.
+// Reservation Pods are counted only in the core namespace, since they are created by the StatefulSet controller
+// for any reservation-labelled StatefulSet and users can create such StatefulSets in their own namespaces.
+func (r *MachineReconciler) getMachineGroupPods(ctx context.Context, machineGroup string) (map[string]machineTypePods, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.MatchingFields{consts.MachineGroupField: machineGroup}); err != nil {
+		return nil, err
+	}
+
+	podsByMachineType := make(map[string]machineTypePods)
+	for _, po := range pods.Items {
+		if po.Labels[consts.MachineGroupKey] != machineGroup {
+			continue
+		}
+		mtName, exist := po.Labels[consts.MachineTypeKey]
+		if !exist {
+			continue
+		}
+		mtPods := podsByMachineType[mtName]
+		switch po.Labels[consts.PodRoleKey] {
+		case consts.PodRoleReservation:
+			if po.Namespace != consts.ImperatorCoreNamespace {
+				continue
+			}
+			mtPods.reservation = append(mtPods.reservation, po)
+		case consts.PodRoleGuest:
+			mtPods.guest = append(mtPods.guest, po)
+		}
+		podsByMachineType[mtName] = mtPods
+	}
+	return podsByMachineType, nil
+}
+
+// getInjectionEnabledNamespaces returns names of Namespaces where the pod resource injector is enabled.
+func (r *MachineReconciler) getInjectionEnabledNamespaces(ctx context.Context) (map[string]bool, error) {
+	namespaces := &corev1.NamespaceList{}
+	if err := r.List(ctx, namespaces, &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			consts.ImperatorResourceInjectionKey: consts.ImperatorResourceInjectionEnabled,
+		}),
+	}); err != nil {
+		return nil, err
+	}
+
+	enabledNamespaces := make(map[string]bool, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		enabledNamespaces[ns.Name] = true
+	}
+	return enabledNamespaces, nil
+}
@@ -21,12 +21,11 @@ import (
 	"fmt"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/imdario/mergo"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -40,6 +39,7 @@ import (
 	imperatorv1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
 	"github.com/tenzen-y/imperator/pkg/consts"
 	"github.com/tenzen-y/imperator/pkg/controllers/util"
+	"github.com/tenzen-y/imperator/pkg/metrics"
 )
 
 // MachineReconciler reconciles a Machine object
@@ -58,6 +58,8 @@ type MachineReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;watch
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch;create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -92,6 +94,10 @@ func (r *MachineReconciler) reconcile(ctx context.Context, machine *imperatorv1a
 		logger.Error(err, "failed to reconcile MachineNodePool", "name", machine.Name)
 		return r.updateReconcileFailedStatus(ctx, machine, err)
 	}
+	if err := r.reconcilePriorityClass(ctx, machine); err != nil {
+		logger.Error(err, "failed to reconcile PriorityClass", "name", machine.Name)
+		return r.updateReconcileFailedStatus(ctx, machine, err)
+	}
 	if err := r.reconcileStatefulSet(ctx, machine); err != nil {
 		logger.Error(err, "failed to reconcile StatefulSet", "name", machine.Name)
 		return r.updateReconcileFailedStatus(ctx, machine, err)
@@ -102,6 +108,11 @@ func (r *MachineReconciler) reconcile(ctx context.Context, machine *imperatorv1a
 		return r.updateReconcileFailedStatus(ctx, machine, err)
 	}
 
+	if err := r.pruneReservationResources(ctx, machine); err != nil {
+		logger.Error(err, "failed to prune reservation resources", "name", machine.Name)
+		return r.updateReconcileFailedStatus(ctx, machine, err)
+	}
+
 	return r.updateStatus(ctx, machine)
 }
 
@@ -119,17 +130,33 @@ func (r *MachineReconciler) reconcileMachineNodePool(ctx context.Context, machin
 		},
 	}
 
+	nodePool, err := r.getNodePool(ctx, machine)
+	if err != nil {
+		return err
+	}
+	activeMachineTypes := getActiveMachineTypes(machine, nodePool)
+
 	origin := &imperatorv1alpha1.MachineNodePool{}
+	var prunedMachineTypeStock []string
 	opeResult, err := ctrl.CreateOrUpdate(ctx, r.Client, pool, func() error {
 		origin = pool.DeepCopy()
+		prunedMachineTypeStock = nil
 
+		// remove machineTypeStock for machineTypes removed from .spec.machineTypes or from every nodePool
 		poolMachineTypeStockMap := make(map[string]bool)
+		var machineTypeStock []imperatorv1alpha1.NodePoolMachineTypeStock
 		for _, mts := range pool.Spec.MachineTypeStock {
 			if poolMachineTypeStockMap[mts.Name] {
 				continue
 			}
+			if !activeMachineTypes[mts.Name] {
+				prunedMachineTypeStock = append(prunedMachineTypeStock, mts.Name)
+				continue
+			}
 			poolMachineTypeStockMap[mts.Name] = true
+			machineTypeStock = append(machineTypeStock, mts)
 		}
+		pool.Spec.MachineTypeStock = machineTypeStock
 
 		if pool.Labels == nil {
 			pool.Labels = make(map[string]string)
@@ -139,10 +166,12 @@ func (r *MachineReconciler) reconcileMachineNodePool(ctx context.Context, machin
 		}
 		pool.Spec.MachineGroupName = machineGroup
 
-		nodePoolSpec := machine.Spec.DeepCopy().NodePool
-		pool.Spec.NodePool = nodePoolSpec
+		machineSpec := machine.Spec.DeepCopy()
+		pool.Spec.NodePool = machineSpec.NodePool
+		pool.Spec.NodeSelector = machineSpec.NodeSelector
+		pool.Spec.NodeHealthPolicy = machineSpec.NodeHealthPolicy
 		for _, mt := range machine.Spec.MachineTypes {
-			if poolMachineTypeStockMap[mt.Name] {
+			if !activeMachineTypes[mt.Name] || poolMachineTypeStockMap[mt.Name] {
 				continue
 			}
 			pool.Spec.MachineTypeStock = append(pool.Spec.MachineTypeStock, imperatorv1alpha1.NodePoolMachineTypeStock{
@@ -164,6 +193,9 @@ func (r *MachineReconciler) reconcileMachineNodePool(ctx context.Context, machin
 	if opeResult == controllerutil.OperationResultUpdated {
 		logger.Info(fmt.Sprintf("updated MachineNodePool, %s", pool.Name))
 		logger.Info(cmp.Diff(origin.Spec, pool.Spec, consts.CmpSliceOpts...))
+		for _, mtName := range prunedMachineTypeStock {
+			r.Recorder.Eventf(machine, corev1.EventTypeNormal, "Pruned", "removed machineTypeStock <%s> from MachineNodePool <%s>", mtName, pool.Name)
+		}
 	}
 
 	if err = r.updateReconcileConditions(ctx, opeResult, machine); err != nil {
@@ -173,22 +205,82 @@ func (r *MachineReconciler) reconcileMachineNodePool(ctx context.Context, machin
 	return nil
 }
 
+// reconcilePriorityClass creates PriorityClasses for placeholder Pods and Guest Pods in Preemptible reservation mode.
+// Those are shared by all machineGroups, so those do not have ownerReferences.
+func (r *MachineReconciler) reconcilePriorityClass(ctx context.Context, machine *imperatorv1alpha1.Machine) error {
+	logger := log.FromContext(ctx)
+
+	if machine.Spec.ReservationMode != imperatorv1alpha1.ReservationModePreemptible {
+		return nil
+	}
+
+	priorityClasses := []struct {
+		name             string
+		value            int32
+		preemptionPolicy corev1.PreemptionPolicy
+		description      string
+	}{
+		{
+			name:             consts.ReservationPriorityClassName,
+			value:            consts.ReservationPriority,
+			preemptionPolicy: corev1.PreemptNever,
+			description:      "Used for placeholder Pods reserving resources for imperator machineTypes.",
+		},
+		{
+			name:             consts.GuestPriorityClassName,
+			value:            consts.GuestPriority,
+			preemptionPolicy: corev1.PreemptLowerPriority,
+			description:      "Used for Guest Pods of imperator machineTypes to preempt placeholder Pods.",
+		},
+	}
+
+	for _, p := range priorityClasses {
+		pc := &schedulingv1.PriorityClass{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: schedulingv1.SchemeGroupVersion.String(),
+				Kind:       "PriorityClass",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: p.name,
+			},
+		}
+		opeResult, err := ctrl.CreateOrUpdate(ctx, r.Client, pc, func() error {
+			util.GeneratePriorityClass(p.value, p.preemptionPolicy, p.description, pc)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reconcile PriorityClass, %s; %v", p.name, err)
+		}
+		if opeResult == controllerutil.OperationResultCreated {
+			logger.Info(fmt.Sprintf("created PriorityClass, %s", p.name))
+		}
+		if opeResult == controllerutil.OperationResultUpdated {
+			logger.Info(fmt.Sprintf("updated PriorityClass, %s", p.name))
+		}
+	}
+
+	return nil
+}
+
 func (r *MachineReconciler) reconcileStatefulSet(ctx context.Context, machine *imperatorv1alpha1.Machine) error {
 	logger := log.FromContext(ctx)
 	machineGroup := util.GetMachineGroup(machine.Labels)
 
-	nodePoolMachineTypeMap := make(map[string]bool)
-	for _, np := range machine.Spec.NodePool {
-		for _, npmt := range np.MachineType {
-			if nodePoolMachineTypeMap[npmt.Name] {
-				continue
-			}
-			nodePoolMachineTypeMap[npmt.Name] = true
-		}
+	nodePool, err := r.getNodePool(ctx, machine)
+	if err != nil {
+		return err
+	}
+
+	activeMachineTypes := getActiveMachineTypes(machine, nodePool)
+
+	// machineTypes sharing Nodes compete for the same allocatable resources.
+	sharedCapacity, err := r.getSharedMachineTypeCapacity(ctx, machine, nodePool)
+	if err != nil {
+		return fmt.Errorf("failed to estimate capacity of machineTypes; %v", err)
 	}
 
 	for _, mt := range machine.Spec.MachineTypes {
-		if !nodePoolMachineTypeMap[mt.Name] {
+		if !activeMachineTypes[mt.Name] {
 			continue
 		}
 		sts := &appsv1.StatefulSet{
@@ -216,12 +308,16 @@ func (r *MachineReconciler) reconcileStatefulSet(ctx context.Context, machine *i
 				return err
 			}
 
-			stsReplica := usage.Maximum - (usage.Used + usage.Waiting + unscheduledPodNum)
+			maximum := usage.Maximum
+			if capacity, exist := sharedCapacity[mt.Name]; exist && capacity < maximum {
+				maximum = capacity
+			}
+			stsReplica := maximum - (usage.Used + usage.Waiting + unscheduledPodNum)
 			if stsReplica < 0 {
 				stsReplica = 0
 			}
 
-			util.GenerateStatefulSet(&mt, machineGroup, stsReplica, sts)
+			util.GenerateStatefulSet(&mt, machineGroup, machine.Spec.ReservationMode, stsReplica, sts)
 			return ctrl.SetControllerReference(machine, sts, r.Scheme)
 		})
 
@@ -252,7 +348,16 @@ func (r *MachineReconciler) reconcileService(ctx context.Context, machine *imper
 	logger := log.FromContext(ctx)
 	machineGroup := util.GetMachineGroup(machine.Labels)
 
+	nodePool, err := r.getNodePool(ctx, machine)
+	if err != nil {
+		return err
+	}
+	activeMachineTypes := getActiveMachineTypes(machine, nodePool)
+
 	for _, mt := range machine.Spec.MachineTypes {
+		if !activeMachineTypes[mt.Name] {
+			continue
+		}
 		svc := &corev1.Service{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: corev1.SchemeGroupVersion.String(),
@@ -300,26 +405,48 @@ func (r *MachineReconciler) updateStatus(ctx context.Context, machine *imperator
 	logger := log.FromContext(ctx)
 	machineGroup := util.GetMachineGroup(machine.Labels)
 
+	autoCapacity, err := r.getAutoMachineTypeCapacity(ctx, machine)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
 	desiredMachineTypeNum := make(map[string]int32)
 	for _, mt := range machine.Spec.MachineTypes {
+		if mt.AutoCapacity {
+			desiredMachineTypeNum[mt.Name] = autoCapacity[mt.Name]
+			continue
+		}
 		desiredMachineTypeNum[mt.Name] = mt.Available
 	}
 
+	nodePool, err := r.getNodePool(ctx, machine)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+	activeMachineTypes := getActiveMachineTypes(machine, nodePool)
+
 	originAvailableMachineStatus := machine.Status.DeepCopy().AvailableMachines
 
+	// remove availableMachines for machineTypes removed from .spec.machineTypes or from every nodePool
+	var removedMachineTypes []string
+	machine.Status.AvailableMachines, removedMachineTypes = util.RemoveStaleAvailableMachines(machine.Status.AvailableMachines, activeMachineTypes)
+	for _, mtName := range removedMachineTypes {
+		r.Recorder.Eventf(machine, corev1.EventTypeNormal, "Pruned", "removed availableMachines status for machineType <%s>", mtName)
+		metrics.DeleteMachineTypeUsage(machineGroup, mtName)
+	}
+
 	// if availableMachines is empty, create that
 	availableMachinesMap := make(map[string]bool)
 	for _, am := range machine.Status.AvailableMachines {
 		availableMachinesMap[am.Name] = true
 	}
 	for _, mt := range machine.Spec.MachineTypes {
-		if availableMachinesMap[mt.Name] {
+		if !activeMachineTypes[mt.Name] || availableMachinesMap[mt.Name] {
 			continue
 		}
 		machine.Status.AvailableMachines = append(machine.Status.AvailableMachines, imperatorv1alpha1.AvailableMachineCondition{
 			Name: mt.Name,
 			Usage: imperatorv1alpha1.UsageCondition{
-				Maximum:  mt.Available,
+				Maximum:  desiredMachineTypeNum[mt.Name],
 				Reserved: 0,
 				Used:     0,
 				Waiting:  0,
@@ -327,37 +454,33 @@ func (r *MachineReconciler) updateStatus(ctx context.Context, machine *imperator
 		})
 	}
 
-	for idx, statusMT := range machine.Status.AvailableMachines {
+	// looking for Pods for the machineGroup at once
+	podsByMachineType, err := r.getMachineGroupPods(ctx, machineGroup)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+	enabledNamespaces, err := r.getInjectionEnabledNamespaces(ctx)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
 
-		// prepare labels
-		roleCommonLabels := map[string]string{
-			consts.MachineGroupKey: machineGroup,
-			consts.MachineTypeKey:  statusMT.Name,
-		}
-		reservationRoleLabels := map[string]string{
-			consts.PodRoleKey: consts.PodRoleReservation,
+	// node name -> machineType name -> the number of Pods
+	nodeReserved := make(map[string]map[string]int32)
+	nodeUsed := make(map[string]map[string]int32)
+	countNodePod := func(nodePods map[string]map[string]int32, po *corev1.Pod, machineTypeName string) {
+		if po.Spec.NodeName == "" {
+			return
 		}
-		guestRoleLabels := map[string]string{
-			consts.PodRoleKey: consts.PodRoleGuest,
-		}
-
-		if err := mergo.Map(&reservationRoleLabels, roleCommonLabels, mergo.WithOverride); err != nil {
-			return ctrl.Result{Requeue: true}, err
-		}
-		if err := mergo.Map(&guestRoleLabels, roleCommonLabels, mergo.WithOverride); err != nil {
-			return ctrl.Result{Requeue: true}, err
+		if _, exist := nodePods[po.Spec.NodeName]; !exist {
+			nodePods[po.Spec.NodeName] = make(map[string]int32)
 		}
+		nodePods[po.Spec.NodeName][machineTypeName]++
+	}
 
-		// looking for Pods to reserve resource
-		reservationPods := &corev1.PodList{}
-		if err := r.List(ctx, reservationPods, &client.ListOptions{
-			LabelSelector: labels.SelectorFromSet(reservationRoleLabels),
-		}); err != nil {
-			return ctrl.Result{Requeue: true}, err
-		}
+	for idx, statusMT := range machine.Status.AvailableMachines {
 
 		machine.Status.AvailableMachines[idx].Usage.Reserved = 0
-		for _, po := range reservationPods.Items {
+		for _, po := range podsByMachineType[statusMT.Name].reservation {
 			podConditionTypeMap := util.GetPodConditionTypeMap(po.Status.Conditions)
 			// Terminating
 			if po.ObjectMeta.DeletionTimestamp != nil {
@@ -366,29 +489,19 @@ func (r *MachineReconciler) updateStatus(ctx context.Context, machine *imperator
 			// Running
 			if po.Status.Phase == corev1.PodRunning && podConditionTypeMap[corev1.ContainersReady].Status == corev1.ConditionTrue {
 				machine.Status.AvailableMachines[idx].Usage.Reserved++
+				countNodePod(nodeReserved, &po, statusMT.Name)
 				// ContainerCreating
 			} else if po.Status.Phase == corev1.PodPending && po.Spec.NodeName != "" {
 				machine.Status.AvailableMachines[idx].Usage.Reserved++
+				countNodePod(nodeReserved, &po, statusMT.Name)
 			}
 		}
 
-		// looking for guest Pods
-		guestPods := &corev1.PodList{}
-		if err := r.List(ctx, guestPods, &client.ListOptions{
-			LabelSelector: labels.SelectorFromSet(guestRoleLabels),
-		}); err != nil {
-			return ctrl.Result{Requeue: true}, err
-		}
-
 		machine.Status.AvailableMachines[idx].Usage.Used = 0
 		machine.Status.AvailableMachines[idx].Usage.Waiting = 0
-		for _, po := range guestPods.Items {
-
-			ns := &corev1.Namespace{}
-			if err := r.Get(ctx, client.ObjectKey{Name: po.Namespace}, ns); err != nil {
-				return ctrl.Result{Requeue: true}, err
-			}
-			if injectionLabel := ns.Labels[consts.ImperatorResourceInjectionKey]; injectionLabel != consts.ImperatorResourceInjectionEnabled {
+		namespaceUsed := make(map[string]int32)
+		for _, po := range podsByMachineType[statusMT.Name].guest {
+			if !enabledNamespaces[po.Namespace] {
 				continue
 			}
 
@@ -402,11 +515,19 @@ func (r *MachineReconciler) updateStatus(ctx context.Context, machine *imperator
 			// Running
 			if po.Status.Phase == corev1.PodRunning && podConditionTypeMap[corev1.ContainersReady].Status == corev1.ConditionTrue {
 				machine.Status.AvailableMachines[idx].Usage.Used++
+				namespaceUsed[po.Namespace]++
+				countNodePod(nodeUsed, &po, statusMT.Name)
 			} else if po.Status.Phase == corev1.PodPending {
 
 				// ContainerCreating
 				if po.Spec.NodeName != "" {
 					machine.Status.AvailableMachines[idx].Usage.Used++
+					namespaceUsed[po.Namespace]++
+					countNodePod(nodeUsed, &po, statusMT.Name)
+					// Pod preempted placeholder Pods and is waiting for those to terminate
+				} else if po.Status.NominatedNodeName != "" {
+					machine.Status.AvailableMachines[idx].Usage.Used++
+					namespaceUsed[po.Namespace]++
 				} else if scheduledCondition, exist := podConditionTypeMap[corev1.PodScheduled]; exist {
 					// Pod has not yet been scheduled on any Nodes
 					if scheduledCondition.Reason == corev1.PodReasonUnschedulable &&
@@ -417,11 +538,27 @@ func (r *MachineReconciler) updateStatus(ctx context.Context, machine *imperator
 			}
 		}
 
+		// set per-namespace usage
+		machine.Status.AvailableMachines[idx].NamespaceUsage = util.GenerateNamespaceUsage(namespaceUsed)
+
 		// set Usage.Maximum
 		machine.Status.AvailableMachines[idx].Usage.Maximum = desiredMachineTypeNum[statusMT.Name]
+
+		usage := machine.Status.AvailableMachines[idx].Usage
+		metrics.SetMachineTypeUsage(machineGroup, statusMT.Name, usage.Maximum, usage.Reserved, usage.Used, usage.Waiting)
 	}
 
-	if diff := cmp.Diff(originAvailableMachineStatus, machine.Status.AvailableMachines, consts.CmpSliceOpts...); diff != "" {
+	// set per-node usage
+	originNodeStatus := machine.Status.DeepCopy().Nodes
+	machine.Status.Nodes = nil
+	if machine.Spec.ReportNodeStatus {
+		if machine.Status.Nodes, err = r.getNodeUsage(ctx, machine, nodeReserved, nodeUsed); err != nil {
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+
+	if diff := cmp.Diff(originAvailableMachineStatus, machine.Status.AvailableMachines, consts.CmpSliceOpts...) +
+		cmp.Diff(originNodeStatus, machine.Status.Nodes, consts.CmpSliceOpts...); diff != "" {
 		r.Recorder.Eventf(machine, corev1.EventTypeNormal, "Updated", "updated available machine status")
 		if err := r.updateReconcileSuccessStatus(ctx, machine); err != nil {
 			return ctrl.Result{Requeue: true}, err
@@ -438,6 +575,23 @@ func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		return r.podReconcileRequest(ctx, o)
 	})
 
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &corev1.Pod{}, consts.PodNodeNameField, func(o client.Object) []string {
+		nodeName := o.(*corev1.Pod).Spec.NodeName
+		if nodeName == "" {
+			return nil
+		}
+		return []string{nodeName}
+	}); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &corev1.Pod{}, consts.MachineGroupField, machineGroupIndexer); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &imperatorv1alpha1.Machine{}, consts.MachineGroupField, machineGroupIndexer); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&imperatorv1alpha1.Machine{}).
 		Owns(&imperatorv1alpha1.MachineNodePool{}).
@@ -447,47 +601,34 @@ func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 }
 
 func (r *MachineReconciler) podReconcileRequest(ctx context.Context, o client.Object) []reconcile.Request {
-	// check namespace & PodRole
-	ns := &corev1.Namespace{}
-	if err := r.Get(ctx, client.ObjectKey{Name: o.GetNamespace()}, ns); err != nil {
-		return nil
-	}
-	injectionLabel := ns.Labels[consts.ImperatorResourceInjectionKey]
-
+	// Guest Pods in namespaces where injection is disabled are ignored in updateStatus.
 	podLabels := o.GetLabels()
-	podRole := podLabels[consts.PodRoleKey]
-	if injectionLabel != consts.ImperatorResourceInjectionEnabled && podRole == consts.PodRoleGuest {
-		return nil
-	}
 
 	// check MachineGroup
 	machineGroupName, exist := podLabels[consts.MachineGroupKey]
 	if !exist {
 		return nil
 	}
-
-	machines := &imperatorv1alpha1.MachineList{}
-	if err := r.List(ctx, machines, &client.ListOptions{
-		LabelSelector: labels.SelectorFromSet(map[string]string{
-			consts.MachineGroupKey: machineGroupName,
-		}),
-	}); err != nil {
-		return nil
-	}
-	if len(machines.Items) != 1 {
+	machineType, exist := podLabels[consts.MachineTypeKey]
+	if !exist {
 		return nil
 	}
 
-	machineType, exist := podLabels[consts.MachineTypeKey]
-	if !exist {
+	machines := &imperatorv1alpha1.MachineList{}
+	if err := r.List(ctx, machines, client.MatchingFields{consts.MachineGroupField: machineGroupName}); err != nil {
 		return nil
 	}
 
-	for _, mt := range machines.Items[0].Spec.MachineTypes {
-		if mt.Name == machineType {
-			return []reconcile.Request{{
-				NamespacedName: client.ObjectKeyFromObject(&machines.Items[0]),
-			}}
+	for _, m := range machines.Items {
+		if util.GetMachineGroup(m.Labels) != machineGroupName {
+			continue
+		}
+		for _, mt := range m.Spec.MachineTypes {
+			if mt.Name == machineType {
+				return []reconcile.Request{{
+					NamespacedName: client.ObjectKeyFromObject(&m),
+				}}
+			}
 		}
 	}
 
@@ -19,7 +19,6 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -29,11 +28,16 @@ import (
 	"github.com/onsi/gomega/gstruct"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	imperatorv1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
 	"github.com/tenzen-y/imperator/pkg/consts"
@@ -49,6 +53,51 @@ const (
 	testGuestNs                 = "test-guest-ns"
 )
 
+// countingClient counts requests to read objects.
+type countingClient struct {
+	client.Client
+	readCount int
+}
+
+func (c *countingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	c.readCount++
+	return c.Client.Get(ctx, key, obj)
+}
+
+func (c *countingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	c.readCount++
+	return c.Client.List(ctx, list, opts...)
+}
+
+// nodeNameIndexedClient returns only Pods on the Node for the field selector, spec.nodeName like the cache with the index,
+// since the fake client ignores field selectors.
+type nodeNameIndexedClient struct {
+	client.Client
+}
+
+func (c *nodeNameIndexedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	pods, ok := list.(*corev1.PodList)
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	if !ok || listOpts.FieldSelector == nil {
+		return nil
+	}
+	nodeName, found := listOpts.FieldSelector.RequiresExactMatch(consts.PodNodeNameField)
+	if !found {
+		return nil
+	}
+	var items []corev1.Pod
+	for _, po := range pods.Items {
+		if po.Spec.NodeName == nodeName {
+			items = append(items, po)
+		}
+	}
+	pods.Items = items
+	return nil
+}
+
 func waitStartedReservationResource(ctx context.Context, machineType imperatorv1alpha1.MachineType, stsReplicas int32) {
 	stsName := util.GenerateReservationResourceName(testMachineMachineGroupName, machineType.Name)
 	Eventually(func() error {
@@ -270,14 +319,11 @@ var _ = Describe("machine controller envtest", func() {
 		Expect(err).ToNot(HaveOccurred())
 
 		Expect((&MachineReconciler{
-			Client:   k8sClient,
+			Client:   mgr.GetClient(),
 			Scheme:   scheme,
 			Recorder: mgr.GetEventRecorderFor("imperator"),
 		}).SetupWithManager(ctx, mgr)).NotTo(HaveOccurred())
 
-		if err := os.Setenv("ENVTEST", "true"); err != nil {
-			panic(err)
-		}
 		ctx, stopFunc = context.WithCancel(ctx)
 		go func() {
 			err := mgr.Start(ctx)
@@ -416,4 +462,540 @@ var _ = Describe("machine controller envtest", func() {
 			"Waiting":  Equal(int32(0)),
 		})
 	})
+
+	It("Should not count reservation Pods outside the core namespace", func() {
+		machine := newFakeMachine(defaultTestNodePool, defaultTestMachineType)
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+		waitStartedReservationResource(ctx, defaultTestMachineType[testMachine1], defaultTestMachineType[testMachine1].Available)
+
+		// Create Reservation Pod in the guest namespace, e.g. by a reservation-labelled StatefulSet which a user created
+		fakePod := newFakeReservationPod(testMachine1, "0")
+		fakePod.Namespace = testGuestNs
+		Expect(k8sClient.Create(ctx, fakePod, &client.CreateOptions{})).NotTo(HaveOccurred())
+		defer func() {
+			Expect(k8sClient.Delete(ctx, fakePod, &client.DeleteOptions{GracePeriodSeconds: pointer.Int64(0)})).NotTo(HaveOccurred())
+		}()
+		updatePodContainerStatus(ctx, client.ObjectKeyFromObject(fakePod), "running")
+
+		// Create Reservation Pod in the core namespace
+		pod := newFakeReservationPod(testMachine1, "0")
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).NotTo(HaveOccurred())
+		defer func() {
+			Expect(k8sClient.Delete(ctx, pod, &client.DeleteOptions{GracePeriodSeconds: pointer.Int64(0)})).NotTo(HaveOccurred())
+		}()
+		updatePodContainerStatus(ctx, client.ObjectKeyFromObject(pod), "running")
+
+		// Check Machine Status
+		checkMachineAvailableStatus(ctx, testMachine1, gstruct.Fields{
+			"Used":     Equal(int32(0)),
+			"Maximum":  Equal(defaultTestMachineType[testMachine1].Available),
+			"Reserved": Equal(int32(1)),
+			"Waiting":  Equal(int32(0)),
+		})
+	})
+
+	It("Should create PriorityClasses in Preemptible reservation mode", func() {
+		machine := newFakeMachine(defaultTestNodePool, defaultTestMachineType)
+		machine.Spec.ReservationMode = imperatorv1alpha1.ReservationModePreemptible
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		// Check {APIVersion: scheduling.k8s.io/v1, Kind: PriorityClass}
+		for name, value := range map[string]int32{
+			consts.ReservationPriorityClassName: consts.ReservationPriority,
+			consts.GuestPriorityClassName:       consts.GuestPriority,
+		} {
+			pc := &schedulingv1.PriorityClass{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, client.ObjectKey{Name: name}, pc)
+			}, consts.SuiteTestTimeOut).Should(BeNil())
+			Expect(pc.Value).Should(Equal(value))
+		}
+
+		// Check PriorityClass of placeholder Pods
+		for _, mt := range defaultTestMachineType {
+			Eventually(func() string {
+				sts := &appsv1.StatefulSet{}
+				if err := k8sClient.Get(ctx, client.ObjectKey{
+					Name:      util.GenerateReservationResourceName(testMachineMachineGroupName, mt.Name),
+					Namespace: consts.ImperatorCoreNamespace,
+				}, sts); err != nil {
+					return ""
+				}
+				return sts.Spec.Template.Spec.PriorityClassName
+			}, consts.SuiteTestTimeOut).Should(Equal(consts.ReservationPriorityClassName))
+		}
+	})
+
+	It("Should prune reservation resources for removed machineType", func() {
+		machine := newFakeMachine(defaultTestNodePool, defaultTestMachineType)
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+		for _, mt := range defaultTestMachineType {
+			waitStartedReservationResource(ctx, mt, mt.Available)
+		}
+
+		// Remove test-machine2 from machineTypes and nodePool
+		Eventually(func() error {
+			getMachine := &imperatorv1alpha1.Machine{}
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), getMachine); err != nil {
+				return err
+			}
+			getMachine.Spec = newFakeMachine(
+				map[string]imperatorv1alpha1.NodePool{testNode1: defaultTestNodePool[testNode1]},
+				map[string]imperatorv1alpha1.MachineType{testMachine1: defaultTestMachineType[testMachine1]},
+			).Spec
+			return k8sClient.Update(ctx, getMachine, &client.UpdateOptions{})
+		}, consts.SuiteTestTimeOut).Should(BeNil())
+
+		// Check {APIVersion: apps/v1, Kind: StatefulSet}, {APIVersion: v1, Kind: Service}
+		reservationResourceKey := client.ObjectKey{
+			Name:      util.GenerateReservationResourceName(testMachineMachineGroupName, testMachine2),
+			Namespace: consts.ImperatorCoreNamespace,
+		}
+		Eventually(func() bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, reservationResourceKey, &appsv1.StatefulSet{}))
+		}, consts.SuiteTestTimeOut).Should(BeTrue())
+		Eventually(func() bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, reservationResourceKey, &corev1.Service{}))
+		}, consts.SuiteTestTimeOut).Should(BeTrue())
+
+		// Check Machine Status
+		Eventually(func() []string {
+			getMachine := &imperatorv1alpha1.Machine{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), getMachine)).NotTo(HaveOccurred())
+			var names []string
+			for _, am := range getMachine.Status.AvailableMachines {
+				names = append(names, am.Name)
+			}
+			return names
+		}, consts.SuiteTestTimeOut).Should(Equal([]string{testMachine1}))
+
+		// Check MachineTypeStock of MachineNodePool
+		Eventually(func() []imperatorv1alpha1.NodePoolMachineTypeStock {
+			pool := &imperatorv1alpha1.MachineNodePool{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: util.GenerateMachineNodePoolName(testMachineMachineGroupName)}, pool)).NotTo(HaveOccurred())
+			return pool.Spec.MachineTypeStock
+		}, consts.SuiteTestTimeOut).Should(Equal([]imperatorv1alpha1.NodePoolMachineTypeStock{{Name: testMachine1}}))
+	})
+
+	It("Should prune status for machineType removed from every nodePool", func() {
+		machine := newFakeMachine(defaultTestNodePool, defaultTestMachineType)
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+		for _, mt := range defaultTestMachineType {
+			waitStartedReservationResource(ctx, mt, mt.Available)
+		}
+
+		// Remove test-machine2 from nodePool only
+		Eventually(func() error {
+			getMachine := &imperatorv1alpha1.Machine{}
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), getMachine); err != nil {
+				return err
+			}
+			getMachine.Spec = newFakeMachine(
+				map[string]imperatorv1alpha1.NodePool{testNode1: defaultTestNodePool[testNode1]},
+				defaultTestMachineType,
+			).Spec
+			return k8sClient.Update(ctx, getMachine, &client.UpdateOptions{})
+		}, consts.SuiteTestTimeOut).Should(BeNil())
+
+		// Check Machine Status
+		Eventually(func() []string {
+			getMachine := &imperatorv1alpha1.Machine{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), getMachine)).NotTo(HaveOccurred())
+			var names []string
+			for _, am := range getMachine.Status.AvailableMachines {
+				names = append(names, am.Name)
+			}
+			return names
+		}, consts.SuiteTestTimeOut).Should(Equal([]string{testMachine1}))
+
+		// Check MachineTypeStock of MachineNodePool
+		Eventually(func() []imperatorv1alpha1.NodePoolMachineTypeStock {
+			pool := &imperatorv1alpha1.MachineNodePool{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: util.GenerateMachineNodePoolName(testMachineMachineGroupName)}, pool)).NotTo(HaveOccurred())
+			return pool.Spec.MachineTypeStock
+		}, consts.SuiteTestTimeOut).Should(Equal([]imperatorv1alpha1.NodePoolMachineTypeStock{{Name: testMachine1}}))
+
+		// Check Events
+		Eventually(func() []string {
+			events := &corev1.EventList{}
+			Expect(k8sClient.List(ctx, events, &client.ListOptions{})).NotTo(HaveOccurred())
+			var messages []string
+			for _, e := range events.Items {
+				if e.InvolvedObject.UID == machine.UID && e.Reason == "Pruned" {
+					messages = append(messages, e.Message)
+				}
+			}
+			return messages
+		}, consts.SuiteTestTimeOut).Should(ContainElements(
+			fmt.Sprintf("removed availableMachines status for machineType <%s>", testMachine2),
+			fmt.Sprintf("removed machineTypeStock <%s> from MachineNodePool <%s>", testMachine2,
+				util.GenerateMachineNodePoolName(testMachineMachineGroupName)),
+		))
+	})
+
+	It("Should report the number of Pods on each Node", func() {
+		machine := newFakeMachine(defaultTestNodePool, defaultTestMachineType)
+		machine.Spec.ReportNodeStatus = true
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		// Create Reservation Pod on test-node1
+		pod := newFakeReservationPod(testMachine1, "node-status")
+		pod.Spec.NodeName = testNode1
+		Expect(k8sClient.Create(ctx, pod, &client.CreateOptions{})).NotTo(HaveOccurred())
+		defer func() {
+			Expect(k8sClient.Delete(ctx, pod, &client.DeleteOptions{GracePeriodSeconds: pointer.Int64(0)})).NotTo(HaveOccurred())
+		}()
+		updatePodContainerStatus(ctx, client.ObjectKeyFromObject(pod), "running")
+
+		Eventually(func() []imperatorv1alpha1.NodeUsageCondition {
+			getMachine := &imperatorv1alpha1.Machine{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), getMachine)).NotTo(HaveOccurred())
+			return getMachine.Status.Nodes
+		}, consts.SuiteTestTimeOut).Should(Equal([]imperatorv1alpha1.NodeUsageCondition{
+			{
+				Name: testNode1,
+				MachineTypes: []imperatorv1alpha1.NodeMachineTypeUsage{
+					{Name: testMachine1, Reserved: 1, Used: 0},
+				},
+			},
+			{
+				Name: testNode2,
+				MachineTypes: []imperatorv1alpha1.NodeMachineTypeUsage{
+					{Name: testMachine2, Reserved: 0, Used: 0},
+				},
+			},
+		}))
+	})
+
+	It("Should calculate maximum of machineType with autoCapacity from allocatable resources of Nodes", func() {
+		// testNode2 has 16 CPUs and 64Gi memory, and a DaemonSet Pod requests 4 CPUs.
+		Eventually(func() error {
+			node := &corev1.Node{}
+			if err := k8sClient.Get(ctx, client.ObjectKey{Name: testNode2}, node); err != nil {
+				return err
+			}
+			node.Status.Allocatable = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("16"),
+				corev1.ResourceMemory: resource.MustParse("64Gi"),
+			}
+			return k8sClient.Status().Update(ctx, node, &client.UpdateOptions{})
+		}, consts.SuiteTestTimeOut).Should(BeNil())
+
+		dsPod := &corev1.Pod{
+			TypeMeta: getPodTypeMeta(),
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-daemonset-pod",
+				Namespace: consts.ImperatorCoreNamespace,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+					Kind:       "DaemonSet",
+					Name:       "test-daemonset",
+					UID:        "test-daemonset-uid",
+					Controller: pointer.Bool(true),
+				}},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: testNode2,
+				Containers: []corev1.Container{{
+					Name:  "test-container",
+					Image: "test-image",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("4"),
+						},
+					},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, dsPod, &client.CreateOptions{})).NotTo(HaveOccurred())
+		defer func() {
+			Expect(k8sClient.Delete(ctx, dsPod, &client.DeleteOptions{GracePeriodSeconds: pointer.Int64(0)})).NotTo(HaveOccurred())
+		}()
+
+		autoMachineType := defaultTestMachineType[testMachine2]
+		autoMachineType.Available = 0
+		autoMachineType.AutoCapacity = true
+		machine := newFakeMachine(defaultTestNodePool, map[string]imperatorv1alpha1.MachineType{
+			testMachine1: defaultTestMachineType[testMachine1],
+			testMachine2: autoMachineType,
+		})
+		Expect(k8sClient.Create(ctx, machine, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		// MachineNodePool controller is not running in this suite.
+		updateNodeCondition := func(condition imperatorv1alpha1.MachineNodeCondition) {
+			Eventually(func() error {
+				pool := &imperatorv1alpha1.MachineNodePool{}
+				if err := k8sClient.Get(ctx, client.ObjectKey{Name: util.GenerateMachineNodePoolName(testMachineMachineGroupName)}, pool); err != nil {
+					return err
+				}
+				pool.Status.NodePoolCondition = []imperatorv1alpha1.NodePoolCondition{
+					{Name: testNode1, NodeCondition: imperatorv1alpha1.NodeHealthy},
+					{Name: testNode2, NodeCondition: condition},
+				}
+				return k8sClient.Status().Update(ctx, pool, &client.UpdateOptions{})
+			}, consts.SuiteTestTimeOut).Should(BeNil())
+		}
+		getMaximum := func() int32 {
+			getMachine := &imperatorv1alpha1.Machine{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(machine), getMachine)).NotTo(HaveOccurred())
+			usage := util.GetMachineTypeUsage(getMachine.Status.AvailableMachines, testMachine2)
+			if usage == nil {
+				return -1
+			}
+			return usage.Maximum
+		}
+
+		// (16 - 4) CPUs / 6 CPUs = 2, 64Gi / 32Gi = 2
+		updateNodeCondition(imperatorv1alpha1.NodeHealthy)
+		Eventually(getMaximum, consts.SuiteTestTimeOut).Should(Equal(int32(2)))
+		waitStartedReservationResource(ctx, autoMachineType, 2)
+
+		updateNodeCondition(imperatorv1alpha1.NodeUnhealthy)
+		Eventually(getMaximum, consts.SuiteTestTimeOut).Should(Equal(int32(0)))
+	})
+})
+
+var _ = Describe("machine controller API calls", func() {
+	ctx := context.TODO()
+
+	countUpdateStatusReadRequests := func(podNum int) int {
+		machine := newFakeMachine(map[string]imperatorv1alpha1.NodePool{}, map[string]imperatorv1alpha1.MachineType{
+			testMachine1: {Name: testMachine1, Available: int32(podNum)},
+			testMachine2: {Name: testMachine2, Available: int32(podNum)},
+		})
+		guestNs := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testGuestNs,
+				Labels: map[string]string{
+					consts.ImperatorResourceInjectionKey: consts.ImperatorResourceInjectionEnabled,
+				},
+			},
+		}
+		objs := []client.Object{machine, guestNs}
+		for _, mtName := range []string{testMachine1, testMachine2} {
+			for i := 0; i < podNum; i++ {
+				guestPod := newFakeGuestPod(mtName)
+				guestPod.Name = fmt.Sprintf("%s-%s-%d", guestPod.Name, mtName, i)
+				objs = append(objs, newFakeReservationPod(mtName, strconv.Itoa(i)), guestPod)
+			}
+		}
+
+		c := &countingClient{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()}
+		r := &MachineReconciler{
+			Client:   c,
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(100),
+		}
+		_, err := r.updateStatus(ctx, machine)
+		Expect(err).NotTo(HaveOccurred())
+		return c.readCount
+	}
+
+	// The fake client ignores field selectors, so this checks only that Pods are read in a constant number of requests,
+	// not that the requests are served by the field index.
+	It("The number of read requests in updateStatus is constant regardless of the number of Pods", func() {
+		Expect(countUpdateStatusReadRequests(1)).To(Equal(countUpdateStatusReadRequests(50)))
+	})
+})
+
+var _ = Describe("machine controller shared capacity", func() {
+	ctx := context.TODO()
+
+	It("Resources of placed units are subtracted from free resources of Nodes", func() {
+		const smallMachine, xlargeMachine = "test-machine-small", "test-machine-xlarge"
+		machine := newFakeMachine(map[string]imperatorv1alpha1.NodePool{
+			testNode1: {
+				Name: testNode1,
+				Mode: imperatorv1alpha1.NodeModeReady,
+				MachineType: []imperatorv1alpha1.NodePoolMachineType{
+					{Name: smallMachine},
+					{Name: xlargeMachine},
+				},
+			},
+		}, map[string]imperatorv1alpha1.MachineType{
+			smallMachine: {
+				Name: smallMachine,
+				Spec: imperatorv1alpha1.MachineDetailSpec{
+					CPU:    resource.MustParse("4"),
+					Memory: resource.MustParse("4Gi"),
+				},
+				Available: 4,
+			},
+			xlargeMachine: {
+				Name: xlargeMachine,
+				Spec: imperatorv1alpha1.MachineDetailSpec{
+					CPU:    resource.MustParse("8"),
+					Memory: resource.MustParse("8Gi"),
+				},
+				Available: 2,
+			},
+		})
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: testNode1},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("16"),
+					corev1.ResourceMemory: resource.MustParse("64Gi"),
+				},
+			},
+		}
+		objs := []client.Object{machine, node}
+		for i := 0; i < 2; i++ {
+			guestPod := newFakeGuestPod(xlargeMachine)
+			guestPod.Name = fmt.Sprintf("%s-%d", guestPod.Name, i)
+			guestPod.Spec.NodeName = testNode1
+			guestPod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			}
+			objs = append(objs, guestPod)
+		}
+
+		r := &MachineReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(100),
+		}
+		// two xlarge units use all 16 CPUs of testNode1, so no small unit can be placed.
+		capacity, err := r.getSharedMachineTypeCapacity(ctx, machine, machine.Spec.NodePool)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capacity).To(Equal(map[string]int32{
+			smallMachine:  0,
+			xlargeMachine: 2,
+		}))
+	})
+})
+
+var _ = Describe("machine controller capacity of Nodes", func() {
+	ctx := context.TODO()
+	const smallMachine, xlargeMachine, autoMachine = "test-machine-small", "test-machine-xlarge", "test-machine-auto"
+
+	newFakeNode := func(name, cpu string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse("64Gi"),
+				},
+			},
+		}
+	}
+	newFakePodOnNode := func(name, nodeName, cpu string) *corev1.Pod {
+		pod := newFakeGuestPod(xlargeMachine)
+		pod.Name = name
+		pod.Spec.NodeName = nodeName
+		pod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+		}
+		return pod
+	}
+
+	It("Shared capacity is estimated from Pods on each Node with a request per Node", func() {
+		nodePool := map[string]imperatorv1alpha1.NodePool{}
+		for _, nodeName := range []string{testNode1, testNode2} {
+			nodePool[nodeName] = imperatorv1alpha1.NodePool{
+				Name: nodeName,
+				Mode: imperatorv1alpha1.NodeModeReady,
+				MachineType: []imperatorv1alpha1.NodePoolMachineType{
+					{Name: smallMachine},
+					{Name: xlargeMachine},
+				},
+			}
+		}
+		machine := newFakeMachine(nodePool, map[string]imperatorv1alpha1.MachineType{
+			smallMachine: {
+				Name: smallMachine,
+				Spec: imperatorv1alpha1.MachineDetailSpec{
+					CPU:    resource.MustParse("4"),
+					Memory: resource.MustParse("4Gi"),
+				},
+				Available: 4,
+			},
+			xlargeMachine: {
+				Name: xlargeMachine,
+				Spec: imperatorv1alpha1.MachineDetailSpec{
+					CPU:    resource.MustParse("8"),
+					Memory: resource.MustParse("8Gi"),
+				},
+				Available: 2,
+			},
+		})
+		// two xlarge units use all 16 CPUs of testNode1, and testNode2 is empty.
+		objs := []client.Object{machine, newFakeNode(testNode1, "16"), newFakeNode(testNode2, "16")}
+		for i := 0; i < 2; i++ {
+			objs = append(objs, newFakePodOnNode(fmt.Sprintf("test-guest-pod-%d", i), testNode1, "8"))
+		}
+
+		c := &countingClient{Client: &nodeNameIndexedClient{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()}}
+		r := &MachineReconciler{
+			Client:   c,
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(100),
+		}
+		capacity, err := r.getSharedMachineTypeCapacity(ctx, machine, machine.Spec.NodePool)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capacity).To(Equal(map[string]int32{
+			smallMachine:  4,
+			xlargeMachine: 2,
+		}))
+		// a request for Nodes and a request for Pods on each Node
+		Expect(c.readCount).To(Equal(3))
+	})
+
+	It("Auto capacity subtracts only DaemonSet Pods on healthy Nodes", func() {
+		nodePool := map[string]imperatorv1alpha1.NodePool{}
+		for _, nodeName := range []string{testNode1, testNode2} {
+			nodePool[nodeName] = imperatorv1alpha1.NodePool{
+				Name:        nodeName,
+				Mode:        imperatorv1alpha1.NodeModeReady,
+				MachineType: []imperatorv1alpha1.NodePoolMachineType{{Name: autoMachine}},
+			}
+		}
+		machine := newFakeMachine(nodePool, map[string]imperatorv1alpha1.MachineType{
+			autoMachine: {
+				Name: autoMachine,
+				Spec: imperatorv1alpha1.MachineDetailSpec{
+					CPU:    resource.MustParse("4"),
+					Memory: resource.MustParse("4Gi"),
+				},
+				AutoCapacity: true,
+			},
+		})
+		pool := &imperatorv1alpha1.MachineNodePool{
+			ObjectMeta: metav1.ObjectMeta{Name: util.GenerateMachineNodePoolName(testMachineMachineGroupName)},
+			Status: imperatorv1alpha1.MachineNodePoolStatus{
+				NodePoolCondition: []imperatorv1alpha1.NodePoolCondition{
+					{Name: testNode1, NodeCondition: imperatorv1alpha1.NodeHealthy},
+					{Name: testNode2, NodeCondition: imperatorv1alpha1.NodeHealthy},
+				},
+			},
+		}
+		daemonSetPod := newFakePodOnNode("test-daemonset-pod", testNode1, "4")
+		daemonSetPod.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "DaemonSet",
+			Name:       "test-daemonset",
+			UID:        "test-daemonset-uid",
+			Controller: pointer.Bool(true),
+		}}
+		objs := []client.Object{
+			machine, pool, newFakeNode(testNode1, "16"), newFakeNode(testNode2, "8"),
+			daemonSetPod, newFakePodOnNode("test-guest-pod", testNode1, "8"),
+		}
+
+		c := &countingClient{Client: &nodeNameIndexedClient{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()}}
+		r := &MachineReconciler{
+			Client:   c,
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(100),
+		}
+		// testNode1 has 12 CPUs except for the DaemonSet Pod, and testNode2 has 8 CPUs.
+		capacity, err := r.getAutoMachineTypeCapacity(ctx, machine)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capacity).To(Equal(map[string]int32{autoMachine: 5}))
+		// a request for MachineNodePool, a request for Nodes and a request for Pods on each Node
+		Expect(c.readCount).To(Equal(4))
+	})
 })
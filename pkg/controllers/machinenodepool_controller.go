@@ -18,16 +18,25 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -42,13 +51,27 @@ import (
 	imperatorv1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
 	"github.com/tenzen-y/imperator/pkg/consts"
 	"github.com/tenzen-y/imperator/pkg/controllers/util"
+	"github.com/tenzen-y/imperator/pkg/metrics"
 )
 
+// machineNodeConditions is all conditions exported as metrics.
+var machineNodeConditions = []string{
+	string(imperatorv1alpha1.NodeHealthy),
+	string(imperatorv1alpha1.NodeMaintenance),
+	string(imperatorv1alpha1.NodeUnhealthy),
+	string(imperatorv1alpha1.NodeMissing),
+	string(imperatorv1alpha1.NodeDraining),
+	string(imperatorv1alpha1.NodeDrained),
+}
+
 // MachineNodePoolReconciler reconciles a MachineNodePool object
 type MachineNodePoolReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// kubeClient is used to evict Pods since the controller-runtime client does not support the eviction subresource.
+	kubeClient kubernetes.Interface
 }
 
 // +kubebuilder:rbac:groups=imperator.tenzen-y.io,resources=machinenodepools,verbs=get;list;watch;create;update;patch;delete
@@ -57,6 +80,8 @@ type MachineNodePoolReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=nodes/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
 
 // Reconcile is main function for reconciliation loop
 func (r *MachineNodePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -93,6 +118,7 @@ func (r *MachineNodePoolReconciler) reconcile(ctx context.Context, pool *imperat
 			if err := r.cleanupNode(ctx, pool); err != nil {
 				return ctrl.Result{Requeue: true}, err
 			}
+			r.cleanupMetrics(pool)
 			controllerutil.RemoveFinalizer(pool, consts.MachineNodePoolFinalizer)
 			if err := r.Update(ctx, pool); err != nil {
 				return ctrl.Result{Requeue: true}, err
@@ -117,234 +143,477 @@ func (r *MachineNodePoolReconciler) reconcile(ctx context.Context, pool *imperat
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	return r.updateStatus(ctx, pool)
+	drainConditions, err := r.drainNodes(ctx, pool)
+	if err != nil {
+		logger.Error(err, "failed to drain Nodes", "name", pool.Name)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	result, err := r.updateStatus(ctx, pool, drainConditions)
+	if err != nil {
+		return result, err
+	}
+	// Pods are not watched, so the pool is requeued until all Guest Pods are evicted.
+	for _, c := range drainConditions {
+		if c.NodeCondition == imperatorv1alpha1.NodeDraining {
+			return ctrl.Result{RequeueAfter: consts.DrainRequeueInterval}, nil
+		}
+	}
+	return result, nil
 }
 
 func (r *MachineNodePoolReconciler) cleanupNode(ctx context.Context, pool *imperatorv1alpha1.MachineNodePool) error {
-	logger := log.FromContext(ctx)
-	for _, p := range pool.Spec.NodePool {
-
-		node := &corev1.Node{}
-		if err := r.Get(ctx, client.ObjectKey{Name: p.Name}, node); err != nil && !errors.IsNotFound(err) {
-			return err
-		}
-		originNode := node.DeepCopy()
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, &client.ListOptions{}); err != nil {
+		return err
+	}
+	// the pool being deleted is excluded from the shared state.
+	sharedStates, err := r.getSharedNodeStates(ctx, nodes.Items)
+	if err != nil {
+		return err
+	}
 
-		r.removeNodeAnnotation(node)
-		annotationDiff := cmp.Diff(originNode.Annotations, node.Annotations)
-		if annotationDiff != "" {
-			logger.Info(annotationDiff, "nodeName", node.Name)
+	for _, n := range nodes.Items {
+		if !util.ContainsMachineGroup(n.Annotations, pool.Spec.MachineGroupName) {
+			continue
 		}
-
-		r.removeNodeLabel(pool, node)
-		labelDiff := cmp.Diff(originNode.Labels, node.Labels)
-		if labelDiff != "" {
-			logger.Info(labelDiff, "nodeName", node.Name)
+		if err := r.removeNodeFromPool(ctx, pool, n.DeepCopy(), sharedStates[n.Name]); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		r.removeNodeTaint(pool, node)
-		taintDiff := cmp.Diff(originNode.Spec.Taints, node.Spec.Taints, consts.CmpSliceOpts...)
-		if taintDiff != "" {
-			logger.Info(taintDiff, "nodeName", node.Name)
-		}
+// cleanupMetrics removes metrics for the pool's machineGroup.
+func (r *MachineNodePoolReconciler) cleanupMetrics(pool *imperatorv1alpha1.MachineNodePool) {
+	metrics.DeleteNodePoolNodes(pool.Spec.MachineGroupName, machineNodeConditions)
+	for _, mt := range pool.Spec.MachineTypeStock {
+		metrics.DeleteMachineTypeUsage(pool.Spec.MachineGroupName, mt.Name)
+	}
+}
 
-		if annotationDiff == "" && labelDiff == "" && taintDiff == "" {
-			return nil
-		}
+// removeNodeFromPool removes annotation, labels and taints for the pool's machineGroup from the Node.
+// shared is the state aggregated from the other MachineNodePools; nil means no machineGroups remain on the Node.
+func (r *MachineNodePoolReconciler) removeNodeFromPool(ctx context.Context, pool *imperatorv1alpha1.MachineNodePool, node *corev1.Node, shared *sharedNodeState) error {
+	logger := log.FromContext(ctx)
 
-		if err := r.Update(ctx, node, &client.UpdateOptions{}); err != nil {
-			logger.Error(err, fmt.Sprintf("unable to remove annotation, label or taint from %s", node.Name), "nodeName", node.Name)
-			return err
-		}
+	if err := r.applyNode(ctx, pool, node, nil, shared); err != nil {
+		logger.Error(err, fmt.Sprintf("unable to remove annotation, label or taint from %s", node.Name), "nodeName", node.Name)
+		return err
+	}
 
-		r.Recorder.Eventf(pool, corev1.EventTypeNormal, "Updated", fmt.Sprintf("cleanup annotation, label and taint from %s", node.Name))
+	r.Recorder.Eventf(pool, corev1.EventTypeNormal, "Updated", fmt.Sprintf("cleanup annotation, label and taint from %s", node.Name))
 
-	}
 	return nil
 }
 
-func (r *MachineNodePoolReconciler) removeNodeAnnotation(node *corev1.Node) {
-	delete(node.Annotations, consts.MachineGroupKey)
+// sharedNodeState is the state of the Node shared by all machineGroups the Node belongs to.
+type sharedNodeState struct {
+	machineGroups []string
+	mode          imperatorv1alpha1.NodePoolMode
+	taint         bool
 }
 
-func (r *MachineNodePoolReconciler) removeNodeLabel(pool *imperatorv1alpha1.MachineNodePool, node *corev1.Node) {
-	// remove machine status from label
-	delete(node.Labels, consts.MachineStatusKey)
-
-	// remove machineType from label
-	scheduleMachineTypeKeys := make(map[string][]string, len(pool.Spec.NodePool))
-	for _, p := range pool.Spec.NodePool {
-		scheduleMachineTypeKeys[p.Name] = util.GetScheduleMachineTypeKeys(p.MachineType)
+// getSharedNodeStates aggregates nodePools of all MachineNodePools which are not being deleted for each Node.
+// Since all machineGroups compute the same state, the shared label, taint and annotation do not flap.
+func (r *MachineNodePoolReconciler) getSharedNodeStates(ctx context.Context, nodes []corev1.Node) (map[string]*sharedNodeState, error) {
+	pools := &imperatorv1alpha1.MachineNodePoolList{}
+	if err := r.List(ctx, pools, &client.ListOptions{}); err != nil {
+		return nil, err
+	}
+	nodeMap := make(map[string]*corev1.Node, len(nodes))
+	for idx := range nodes {
+		nodeMap[nodes[idx].Name] = &nodes[idx]
 	}
-	if keys, exist := scheduleMachineTypeKeys[node.Name]; exist {
-		for _, mtKey := range keys {
-			if node.Labels[mtKey] != pool.Spec.MachineGroupName {
+
+	states := make(map[string]*sharedNodeState)
+	for _, pool := range pools.Items {
+		if !pool.DeletionTimestamp.IsZero() {
+			continue
+		}
+		nodePool, err := imperatorv1alpha1.ResolveNodePool(pool.Spec.NodePool, pool.Spec.NodeSelector, nodes)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range nodePool {
+			node, exist := nodeMap[p.Name]
+			if !exist {
 				continue
 			}
-			delete(node.Labels, mtKey)
+			state, exist := states[p.Name]
+			if !exist {
+				state = &sharedNodeState{mode: imperatorv1alpha1.NodeModeReady}
+				states[p.Name] = state
+			}
+			state.machineGroups = append(state.machineGroups, pool.Spec.MachineGroupName)
+			state.taint = state.taint || p.Taint
+
+			// The most restrictive mode among machineGroups sharing the Node wins.
+			if healthy, _ := util.EvaluateNodeHealth(node, pool.Spec.NodeHealthPolicy); !healthy {
+				state.mode = imperatorv1alpha1.NodeModeNotReady
+			} else if p.Mode == imperatorv1alpha1.NodeModeMaintenance && state.mode == imperatorv1alpha1.NodeModeReady {
+				state.mode = imperatorv1alpha1.NodeModeMaintenance
+			}
 		}
 	}
+	for _, state := range states {
+		sort.Strings(state.machineGroups)
+	}
+	return states, nil
 }
 
-func (r *MachineNodePoolReconciler) removeNodeTaint(pool *imperatorv1alpha1.MachineNodePool, node *corev1.Node) {
-	taints := util.ExtractKeyValueFromTaint(node.Spec.Taints)
+// applyNode makes labels, annotation and taints of the Node desired.
+// p is nodePool of the pool's machineGroup; nil means the Node is removed from the pool.
+//
+// Labels and annotation are managed with server-side apply, so that Imperator owns only its own keys.
+// The machineType labels are owned by the field manager for the machineGroup
+// and the machine status label and machineGroup annotation are owned by the field manager shared by all machineGroups.
+// Taints are updated with optimistic locking since Node taints are an atomic list in server-side apply;
+// applying only Imperator's taints would remove taints set by others, e.g. node.kubernetes.io/unreachable.
+// So taint updates can still conflict with other writers, and those conflicts are retried against the latest Node.
+// Nodes are never created by the apply since Imperator is not allowed to create Nodes.
+func (r *MachineNodePoolReconciler) applyNode(ctx context.Context, pool *imperatorv1alpha1.MachineNodePool, node *corev1.Node,
+	p *imperatorv1alpha1.NodePool, shared *sharedNodeState) error {
+	logger := log.FromContext(ctx)
+	machineGroup := pool.Spec.MachineGroupName
 
-	// if taint has machine-status, remove it.
-	// remove machine status from taint
-	if _, exist := taints[consts.MachineStatusKey]; exist {
-		for index, t := range node.Spec.Taints {
-			if t.Key != consts.MachineStatusKey {
-				continue
-			}
-			node.Spec.Taints = append(node.Spec.Taints[:index], node.Spec.Taints[index+1:]...)
+	// labels owned by the machineGroup
+	machineTypeLabels := make(map[string]string)
+	if p != nil {
+		for _, mtKey := range util.GetScheduleMachineTypeKeys(p.MachineType) {
+			machineTypeLabels[mtKey] = machineGroup
+		}
+	}
+	currentMachineTypeLabels := make(map[string]string)
+	staleLabels := make(map[string]string)
+	for _, mtKey := range util.GetMachineTypeKeys(node.Labels, machineGroup) {
+		currentMachineTypeLabels[mtKey] = node.Labels[mtKey]
+		if _, exist := machineTypeLabels[mtKey]; !exist {
+			staleLabels[mtKey] = node.Labels[mtKey]
+		}
+	}
+	if labelDiff := cmp.Diff(currentMachineTypeLabels, machineTypeLabels); labelDiff != "" {
+		logger.Info(labelDiff, "nodeName", node.Name)
+		ac := corev1ac.Node(node.Name).WithLabels(machineTypeLabels)
+		if err := r.applyNodeConfiguration(ctx, ac, util.GenerateFieldManager(machineGroup), staleLabels, nil); err != nil {
+			return err
 		}
 	}
 
-	// remove machineType from taint
-	scheduleMachineTypeKeys := make(map[string][]string, len(pool.Spec.NodePool))
-	for _, p := range pool.Spec.NodePool {
-		scheduleMachineTypeKeys[p.Name] = util.GetScheduleMachineTypeKeys(p.MachineType)
+	// labels and annotation shared by machineGroups
+	sharedLabels := make(map[string]string)
+	sharedAnnotations := make(map[string]string)
+	if shared != nil {
+		sharedLabels[consts.MachineStatusKey] = shared.mode.Value()
+		sharedAnnotations[consts.MachineGroupKey] = strings.Join(shared.machineGroups, ",")
 	}
-	if keys, exist := scheduleMachineTypeKeys[node.Name]; exist {
-		for _, mtKey := range keys {
-			idx := util.GetTaintKeyIndex(node.Spec.Taints, mtKey)
-			if idx == nil {
-				continue
-			}
-			node.Spec.Taints = append(node.Spec.Taints[:*idx], node.Spec.Taints[*idx+1:]...)
+	currentSharedLabels := make(map[string]string)
+	if v, exist := node.Labels[consts.MachineStatusKey]; exist {
+		currentSharedLabels[consts.MachineStatusKey] = v
+	}
+	currentSharedAnnotations := make(map[string]string)
+	if v, exist := node.Annotations[consts.MachineGroupKey]; exist {
+		currentSharedAnnotations[consts.MachineGroupKey] = v
+	}
+	labelDiff := cmp.Diff(currentSharedLabels, sharedLabels)
+	annotationDiff := cmp.Diff(currentSharedAnnotations, sharedAnnotations)
+	if labelDiff != "" || annotationDiff != "" {
+		logger.Info(labelDiff+annotationDiff, "nodeName", node.Name)
+		var staleSharedLabels, staleSharedAnnotations map[string]string
+		if shared == nil {
+			staleSharedLabels, staleSharedAnnotations = currentSharedLabels, currentSharedAnnotations
+		}
+		ac := corev1ac.Node(node.Name).WithLabels(sharedLabels).WithAnnotations(sharedAnnotations)
+		if err := r.applyNodeConfiguration(ctx, ac, consts.FieldManager, staleSharedLabels, staleSharedAnnotations); err != nil {
+			return err
+		}
+	}
+
+	// taints
+	now := metav1.Now()
+	var desiredTaints []corev1.Taint
+	if shared != nil && shared.taint {
+		desiredTaints = append(desiredTaints, corev1.Taint{
+			Key:       consts.MachineStatusKey,
+			Value:     shared.mode.Value(),
+			Effect:    corev1.TaintEffectNoSchedule,
+			TimeAdded: &now,
+		})
+	}
+	if p != nil && p.Taint {
+		for _, mtKey := range util.GetScheduleMachineTypeKeys(p.MachineType) {
+			desiredTaints = append(desiredTaints, corev1.Taint{
+				Key:       mtKey,
+				Value:     machineGroup,
+				Effect:    corev1.TaintEffectNoSchedule,
+				TimeAdded: &now,
+			})
+		}
+	}
+	removeTaintKeys := util.GetMachineTypeKeys(util.ExtractKeyValueFromTaint(node.Spec.Taints), machineGroup)
+	if shared == nil || !shared.taint {
+		removeTaintKeys = append(removeTaintKeys, consts.MachineStatusKey)
+	}
+	taintDiff := cmp.Diff(node.Spec.Taints, util.MergeTaints(node.Spec.Taints, removeTaintKeys, desiredTaints), consts.CmpSliceOpts...)
+	if taintDiff == "" {
+		return nil
+	}
+	logger.Info(taintDiff, "nodeName", node.Name)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := r.kubeClient.CoreV1().Nodes().Get(ctx, node.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
 		}
+		latest.Spec.Taints = util.MergeTaints(latest.Spec.Taints, removeTaintKeys, desiredTaints)
+		_, err = r.kubeClient.CoreV1().Nodes().Update(ctx, latest, metav1.UpdateOptions{FieldManager: consts.FieldManager})
+		return err
+	})
+}
+
+// applyNodeConfiguration applies ac with fieldManager.
+// Keys in staleLabels and staleAnnotations which still remain are owned by other field managers,
+// e.g. they were set before Imperator began to use server-side apply, so those are removed with a merge patch.
+func (r *MachineNodePoolReconciler) applyNodeConfiguration(ctx context.Context, ac *corev1ac.NodeApplyConfiguration, fieldManager string,
+	staleLabels, staleAnnotations map[string]string) error {
+	applied, err := r.kubeClient.CoreV1().Nodes().Apply(ctx, ac, metav1.ApplyOptions{
+		FieldManager: fieldManager,
+		Force:        true,
+	})
+	if err != nil {
+		return err
+	}
+
+	removeLabels := make(map[string]interface{})
+	for key, value := range staleLabels {
+		if v, exist := applied.Labels[key]; exist && v == value {
+			removeLabels[key] = nil
+		}
+	}
+	removeAnnotations := make(map[string]interface{})
+	for key, value := range staleAnnotations {
+		if v, exist := applied.Annotations[key]; exist && v == value {
+			removeAnnotations[key] = nil
+		}
+	}
+	if len(removeLabels) == 0 && len(removeAnnotations) == 0 {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      removeLabels,
+			"annotations": removeAnnotations,
+		},
+	})
+	if err != nil {
+		return err
 	}
+	_, err = r.kubeClient.CoreV1().Nodes().Patch(ctx, *ac.Name, types.MergePatchType, patch, metav1.PatchOptions{
+		FieldManager: fieldManager,
+	})
+	return err
 }
 
 func (r *MachineNodePoolReconciler) reconcileNode(ctx context.Context, pool *imperatorv1alpha1.MachineNodePool) error {
 	logger := log.FromContext(ctx)
 
-	for _, p := range pool.Spec.NodePool {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, &client.ListOptions{}); err != nil {
+		return err
+	}
+	nodePool, err := imperatorv1alpha1.ResolveNodePool(pool.Spec.NodePool, pool.Spec.NodeSelector, nodes.Items)
+	if err != nil {
+		return err
+	}
+	sharedStates, err := r.getSharedNodeStates(ctx, nodes.Items)
+	if err != nil {
+		return err
+	}
 
-		// cleanup old env
-		node := &corev1.Node{}
-		if err := r.Get(ctx, client.ObjectKey{Name: p.Name}, node); err != nil {
-			return err
+	// remove Nodes which no longer belong to the pool. e.g. labels of the Node no longer match nodeSelector.
+	nodePoolNames := make(map[string]bool, len(nodePool))
+	for _, p := range nodePool {
+		nodePoolNames[p.Name] = true
+	}
+	for _, n := range nodes.Items {
+		if nodePoolNames[n.Name] || !util.ContainsMachineGroup(n.Annotations, pool.Spec.MachineGroupName) {
+			continue
 		}
-		originNode := node.DeepCopy()
-		r.removeNodeLabel(pool, node)
-		if p.Taint {
-			r.removeNodeTaint(pool, node)
+		if err = r.removeNodeFromPool(ctx, pool, n.DeepCopy(), sharedStates[n.Name]); err != nil {
+			return err
 		}
+	}
 
-		// set annotation
-		if node.Annotations == nil {
-			node.Annotations = make(map[string]string)
+	for _, p := range nodePool {
+		node := &corev1.Node{}
+		if err := r.Get(ctx, client.ObjectKey{Name: p.Name}, node); errors.IsNotFound(err) {
+			// The Node will be reconciled again when the Node with the same name is created.
+			logger.Info(fmt.Sprintf("skipped to reconcile missing Node, %s", p.Name), "MachineNodePool", pool.Name)
+			continue
+		} else if err != nil {
+			return err
 		}
-		node.Annotations[consts.MachineGroupKey] = pool.Spec.MachineGroupName
 
-		taints := util.ExtractKeyValueFromTaint(node.Spec.Taints)
-		newPoolMachineStatusValue := imperatorv1alpha1.NodeModeReady
 		// looking for down Node.
-		for _, t := range consts.CannotUseNodeTaints {
-			if _, exist := taints[t]; !exist {
-				continue
-			}
-			if os.Getenv("ENVTEST") == "true" && t == consts.NodeNotReadyTaint {
-				continue
-			}
-			newPoolMachineStatusValue = imperatorv1alpha1.NodeModeNotReady
-			break
+		if healthy, reason := util.EvaluateNodeHealth(node, pool.Spec.NodeHealthPolicy); !healthy {
+			logger.Info(fmt.Sprintf("%s is unhealthy; %s", node.Name, reason), "MachineNodePool", pool.Name)
+		}
+
+		if err := r.applyNode(ctx, pool, node, p.DeepCopy(), sharedStates[p.Name]); err != nil {
+			logger.Error(err, fmt.Sprintf("unable to set Label and Taint to %s", node.Name), "MachineNodePool", pool.Name)
+			return err
 		}
+	}
+	logger.Info("reconcile Node successfully", "name", pool.Name)
+	return nil
+}
 
-		scheduleMachineTypeKey := util.GetScheduleMachineTypeKeys(p.MachineType)
+// drainNodes evicts Guest Pods of the pool's machineGroup from Nodes in maintenance mode with drain policy.
+// It returns Draining or Drained conditions for those Nodes.
+func (r *MachineNodePoolReconciler) drainNodes(ctx context.Context, pool *imperatorv1alpha1.MachineNodePool) (map[string]imperatorv1alpha1.NodePoolCondition, error) {
+	logger := log.FromContext(ctx)
+	drainConditions := make(map[string]imperatorv1alpha1.NodePoolCondition)
 
-		if p.Mode == imperatorv1alpha1.NodeModeMaintenance {
-			newPoolMachineStatusValue = imperatorv1alpha1.NodeModeMaintenance
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, &client.ListOptions{}); err != nil {
+		return nil, err
+	}
+	nodePool, err := imperatorv1alpha1.ResolveNodePool(pool.Spec.NodePool, pool.Spec.NodeSelector, nodes.Items)
+	if err != nil {
+		return nil, err
+	}
+	existingNodes := make(map[string]bool, len(nodes.Items))
+	for _, n := range nodes.Items {
+		existingNodes[n.Name] = true
+	}
+
+	var drainNodePool []imperatorv1alpha1.NodePool
+	for _, p := range nodePool {
+		if p.Mode != imperatorv1alpha1.NodeModeMaintenance || p.Drain == nil || !existingNodes[p.Name] {
+			continue
 		}
+		drainNodePool = append(drainNodePool, p)
+	}
+	if len(drainNodePool) == 0 {
+		return drainConditions, nil
+	}
 
-		// Set Label to Node
-		if node.Labels == nil {
-			node.Labels = make(map[string]string)
+	guestPods := &corev1.PodList{}
+	if err = r.List(ctx, guestPods, &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			consts.MachineGroupKey: pool.Spec.MachineGroupName,
+			consts.PodRoleKey:      consts.PodRoleGuest,
+		}),
+	}); err != nil {
+		return nil, err
+	}
+	nodeGuestPods := make(map[string][]corev1.Pod)
+	for _, po := range guestPods.Items {
+		if po.Spec.NodeName == "" || !po.DeletionTimestamp.IsZero() ||
+			po.Status.Phase == corev1.PodSucceeded || po.Status.Phase == corev1.PodFailed {
+			continue
 		}
-		// set machine status to label
-		node.Labels[consts.MachineStatusKey] = newPoolMachineStatusValue.Value()
-		// set machineType to label
-		for _, mtKey := range scheduleMachineTypeKey {
-			node.Labels[mtKey] = pool.Spec.MachineGroupName
+		nodeGuestPods[po.Spec.NodeName] = append(nodeGuestPods[po.Spec.NodeName], po)
+	}
+
+	previousConditions := make(map[string]imperatorv1alpha1.NodePoolCondition, len(pool.Status.NodePoolCondition))
+	for _, c := range pool.Status.NodePoolCondition {
+		previousConditions[c.Name] = c
+	}
+
+	now := metav1.Now().Rfc3339Copy()
+	for _, p := range drainNodePool {
+		previous := previousConditions[p.Name]
+		startTime := previous.DrainStartTime
+		if startTime == nil {
+			startTime = &now
+			r.Recorder.Eventf(pool, corev1.EventTypeNormal, "Draining", "started to drain Guest Pods from %s", p.Name)
 		}
 
-		if p.Taint {
-			if node.Spec.Taints == nil {
-				node.Spec.Taints = make([]corev1.Taint, 0)
+		pods := nodeGuestPods[p.Name]
+		if len(pods) == 0 {
+			if previous.NodeCondition != imperatorv1alpha1.NodeDrained {
+				r.Recorder.Eventf(pool, corev1.EventTypeNormal, "Drained", "evicted all Guest Pods from %s", p.Name)
 			}
-
-			// set machine status to taint
-			now := metav1.Now()
-			if _, exist := taints[consts.MachineStatusKey]; !exist {
-				node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
-					Key:       consts.MachineStatusKey,
-					Value:     newPoolMachineStatusValue.Value(),
-					Effect:    corev1.TaintEffectNoSchedule,
-					TimeAdded: &now,
-				})
-			} else {
-				if idx := util.GetTaintKeyIndex(node.Spec.Taints, consts.MachineStatusKey); idx != nil {
-					node.Spec.Taints[*idx] = corev1.Taint{
-						Key:       consts.MachineStatusKey,
-						Value:     newPoolMachineStatusValue.Value(),
-						Effect:    corev1.TaintEffectNoSchedule,
-						TimeAdded: &now,
-					}
-				}
+			drainConditions[p.Name] = imperatorv1alpha1.NodePoolCondition{
+				Name:           p.Name,
+				NodeCondition:  imperatorv1alpha1.NodeDrained,
+				DrainStartTime: startTime,
 			}
+			continue
+		}
 
-			// set machineType to taint
-			for _, mtKey := range scheduleMachineTypeKey {
-				if _, exist := taints[mtKey]; !exist {
-					node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{
-						Key:       mtKey,
-						Value:     pool.Spec.MachineGroupName,
-						Effect:    corev1.TaintEffectNoSchedule,
-						TimeAdded: &now,
-					})
-				} else {
-					if idx := util.GetTaintKeyIndex(node.Spec.Taints, mtKey); idx != nil {
-						node.Spec.Taints[*idx] = corev1.Taint{
-							Key:       mtKey,
-							Value:     pool.Spec.MachineGroupName,
-							Effect:    corev1.TaintEffectNoSchedule,
-							TimeAdded: &now,
-						}
-					}
+		deadlineExceeded := p.Drain.DeadlineSeconds != nil &&
+			now.Time.After(startTime.Add(time.Duration(*p.Drain.DeadlineSeconds)*time.Second))
+		for idx := range pods {
+			po := &pods[idx]
+			if deadlineExceeded {
+				// The Pod is deleted without the Eviction API, so PodDisruptionBudgets are not honoured.
+				if err = r.Delete(ctx, po, &client.DeleteOptions{GracePeriodSeconds: p.Drain.GracePeriodSeconds}); errors.IsNotFound(err) {
+					continue
+				} else if err != nil {
+					return nil, fmt.Errorf("failed to delete Guest Pod <%s/%s>; %v", po.Namespace, po.Name, err)
 				}
+				r.Recorder.Eventf(pool, corev1.EventTypeWarning, "DrainDeadlineExceeded",
+					"deleted Guest Pod <%s/%s> from %s ignoring PodDisruptionBudgets since drain deadline exceeded", po.Namespace, po.Name, p.Name)
+				r.Recorder.Eventf(po, corev1.EventTypeWarning, "DrainDeadlineExceeded",
+					"deleted by MachineNodePool <%s> ignoring PodDisruptionBudgets since drain deadline of %s exceeded", pool.Name, p.Name)
+				continue
 			}
-		}
 
-		taintDiff := cmp.Diff(originNode.Spec.Taints, node.Spec.Taints, consts.CmpSliceOpts...)
-		if taintDiff != "" {
-			logger.Info(taintDiff, "nodeName", node.Name)
+			err = r.kubeClient.CoreV1().Pods(po.Namespace).EvictV1(ctx, &policyv1.Eviction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      po.Name,
+					Namespace: po.Namespace,
+				},
+				DeleteOptions: &metav1.DeleteOptions{
+					GracePeriodSeconds: p.Drain.GracePeriodSeconds,
+				},
+			})
+			if errors.IsTooManyRequests(err) {
+				// PodDisruptionBudget does not allow the eviction now.
+				logger.Info(fmt.Sprintf("eviction of Guest Pod <%s/%s> is blocked; %v", po.Namespace, po.Name, err), "MachineNodePool", pool.Name)
+				continue
+			} else if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to evict Guest Pod <%s/%s>; %v", po.Namespace, po.Name, err)
+			}
+			r.Recorder.Eventf(pool, corev1.EventTypeNormal, "Evicted", "evicted Guest Pod <%s/%s> from %s", po.Namespace, po.Name, p.Name)
 		}
 
-		labelDiff := cmp.Diff(originNode.Labels, node.Labels)
-		if labelDiff != "" {
-			logger.Info(labelDiff, "nodeName", node.Name)
-		}
-
-		if taintDiff == "" && labelDiff == "" {
-			continue
+		drainConditions[p.Name] = imperatorv1alpha1.NodePoolCondition{
+			Name:           p.Name,
+			NodeCondition:  imperatorv1alpha1.NodeDraining,
+			DrainStartTime: startTime,
 		}
+	}
 
-		if err := r.Update(ctx, node, &client.UpdateOptions{}); err != nil {
-			logger.Error(err, fmt.Sprintf("unable to set Label and Taint to %s", node.Name), "MachineNodePool", pool.Name)
-			return err
-		}
+	return drainConditions, nil
+}
 
+func (r *MachineNodePoolReconciler) updateStatus(ctx context.Context, pool *imperatorv1alpha1.MachineNodePool,
+	drainConditions map[string]imperatorv1alpha1.NodePoolCondition) (ctrl.Result, error) {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, &client.ListOptions{}); err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+	nodePool, err := imperatorv1alpha1.ResolveNodePool(pool.Spec.NodePool, pool.Spec.NodeSelector, nodes.Items)
+	if err != nil {
+		return ctrl.Result{Requeue: true}, err
 	}
-	logger.Info("reconcile Node successfully", "name", pool.Name)
-	return nil
-}
 
-func (r *MachineNodePoolReconciler) updateStatus(ctx context.Context, pool *imperatorv1alpha1.MachineNodePool) (ctrl.Result, error) {
 	var nodeConditions []imperatorv1alpha1.NodePoolCondition
-	for _, p := range pool.Spec.NodePool {
+	for _, p := range nodePool {
 		node := &corev1.Node{}
-		if err := r.Get(ctx, client.ObjectKey{Name: p.Name}, node); err != nil {
+		if err := r.Get(ctx, client.ObjectKey{Name: p.Name}, node); errors.IsNotFound(err) {
+			nodeConditions = append(nodeConditions, imperatorv1alpha1.NodePoolCondition{
+				Name:          p.Name,
+				NodeCondition: imperatorv1alpha1.NodeMissing,
+			})
+			continue
+		} else if err != nil {
 			return ctrl.Result{Requeue: true}, err
 		}
 
@@ -359,20 +628,36 @@ func (r *MachineNodePoolReconciler) updateStatus(ctx context.Context, pool *impe
 		}
 
 		nc := imperatorv1alpha1.MachineNodeCondition("")
-		if p.Mode == imperatorv1alpha1.NodeModeMaintenance {
+		reason := ""
+		if p.Mode == imperatorv1alpha1.NodeModeMaintenance || nodeLabelCondition == imperatorv1alpha1.NodeModeMaintenance.Value() {
+			// other machineGroups sharing the Node may set maintenance mode.
 			nc = imperatorv1alpha1.NodeMaintenance
 		} else if nodeLabelCondition == imperatorv1alpha1.NodeModeReady.Value() {
 			nc = imperatorv1alpha1.NodeHealthy
 		} else if nodeLabelCondition == imperatorv1alpha1.NodeModeNotReady.Value() {
 			nc = imperatorv1alpha1.NodeUnhealthy
+			_, reason = util.EvaluateNodeHealth(node, pool.Spec.NodeHealthPolicy)
+		}
+
+		// Draining and Drained take precedence over Maintenance.
+		if dc, exist := drainConditions[p.Name]; exist && nc == imperatorv1alpha1.NodeMaintenance {
+			nodeConditions = append(nodeConditions, dc)
+			continue
 		}
 
 		nodeConditions = append(nodeConditions, imperatorv1alpha1.NodePoolCondition{
 			Name:          p.Name,
 			NodeCondition: nc,
+			Reason:        reason,
 		})
 	}
 
+	nodeNum := make(map[string]int)
+	for _, c := range nodeConditions {
+		nodeNum[string(c.NodeCondition)]++
+	}
+	metrics.SetNodePoolNodes(pool.Spec.MachineGroupName, machineNodeConditions, nodeNum)
+
 	if !cmp.Equal(pool.Status.NodePoolCondition, nodeConditions, consts.CmpSliceOpts...) {
 		r.Recorder.Eventf(pool, corev1.EventTypeNormal, "Updated", "updated Node condition in status")
 		pool.Status.NodePoolCondition = nodeConditions
@@ -393,13 +678,20 @@ func (r *MachineNodePoolReconciler) updateStatus(ctx context.Context, pool *impe
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MachineNodePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	kubeClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	r.kubeClient = kubeClient
+
 	nodeHandler := handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
 		return r.nodeReconcileRequest(ctx, o)
 	})
 
 	nodePredicates := predicate.Funcs{
 		CreateFunc: func(createEvent event.CreateEvent) bool {
-			return false
+			// new Nodes may match nodeSelector.
+			return true
 		},
 		UpdateFunc: func(event event.UpdateEvent) bool {
 			newLabels := event.ObjectNew.(*corev1.Node).Labels
@@ -414,12 +706,25 @@ func (r *MachineNodePoolReconciler) SetupWithManager(ctx context.Context, mgr ct
 				return true
 			}
 
+			// ignore heartbeat of conditions
+			newConditions := make(map[corev1.NodeConditionType]corev1.ConditionStatus)
+			for _, c := range event.ObjectNew.(*corev1.Node).Status.Conditions {
+				newConditions[c.Type] = c.Status
+			}
+			oldConditions := make(map[corev1.NodeConditionType]corev1.ConditionStatus)
+			for _, c := range event.ObjectOld.(*corev1.Node).Status.Conditions {
+				oldConditions[c.Type] = c.Status
+			}
+			if !cmp.Equal(newConditions, oldConditions) {
+				return true
+			}
+
 			newTaints := event.ObjectNew.(*corev1.Node).Spec.Taints
 			oldTaints := event.ObjectOld.(*corev1.Node).Spec.Taints
 			return !cmp.Equal(newTaints, oldTaints, consts.CmpSliceOpts...)
 		},
 		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
-			return false
+			return true
 		},
 		GenericFunc: func(genericEvent event.GenericEvent) bool {
 			return false
@@ -438,11 +743,25 @@ func (r *MachineNodePoolReconciler) nodeReconcileRequest(ctx context.Context, o
 		return nil
 	}
 
+	// The Node can belong to multiple machineGroups, so all MachineNodePools owning the Node are reconciled.
+	// MachineNodePools selecting the Node by name or nodeSelector are reconciled as well.
+	node := o.(*corev1.Node)
 	var req []reconcile.Request
 	for _, pool := range pools.Items {
-		if pool.Spec.MachineGroupName == o.(*corev1.Node).Annotations[consts.MachineGroupKey] {
+		if util.ContainsMachineGroup(node.Annotations, pool.Spec.MachineGroupName) ||
+			imperatorv1alpha1.MatchNodeSelector(pool.Spec.NodeSelector, node) ||
+			containsNodePool(pool.Spec.NodePool, node.Name) {
 			req = append(req, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pool)})
 		}
 	}
 	return req
 }
+
+func containsNodePool(nodePool []imperatorv1alpha1.NodePool, nodeName string) bool {
+	for _, p := range nodePool {
+		if p.Name == nodeName {
+			return true
+		}
+	}
+	return false
+}
@@ -18,14 +18,15 @@ package controllers
 
 import (
 	"context"
-	"os"
 	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -253,9 +254,6 @@ var _ = Describe("machinenodepool controller envtest", func() {
 			Recorder: mgr.GetEventRecorderFor("imperator"),
 		}).SetupWithManager(ctx, mgr)).NotTo(HaveOccurred())
 
-		if err := os.Setenv("ENVTEST", "true"); err != nil {
-			panic(err)
-		}
 		ctx, cancel := context.WithCancel(ctx)
 		stopFunc = cancel
 		go func() {
@@ -317,6 +315,170 @@ var _ = Describe("machinenodepool controller envtest", func() {
 		}
 	})
 
+	It("Should keep labels, annotations and taints set by others", func() {
+		foreignTaint := corev1.Taint{
+			Key:    "example.com/foreign",
+			Value:  "true",
+			Effect: corev1.TaintEffectNoSchedule,
+		}
+		node := &corev1.Node{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: readyTestNodeB}, node)).NotTo(HaveOccurred())
+		node.Labels = map[string]string{"example.com/foreign": "true"}
+		node.Annotations = map[string]string{"example.com/foreign": "true"}
+		node.Spec.Taints = append(node.Spec.Taints, foreignTaint)
+		Expect(k8sClient.Update(ctx, node, &client.UpdateOptions{})).NotTo(HaveOccurred())
+
+		pool := newFakeMachineNodePool(testNodes, testMachineTypeStock)
+		Expect(k8sClient.Create(ctx, pool, &client.CreateOptions{})).NotTo(HaveOccurred())
+		waitUpdateTestNode(ctx, testNodes)
+
+		// Imperator owns only its own keys.
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: readyTestNodeB}, node)).NotTo(HaveOccurred())
+		Expect(node.Labels).To(HaveKeyWithValue("example.com/foreign", "true"))
+		Expect(node.Annotations).To(HaveKeyWithValue("example.com/foreign", "true"))
+		Expect(util.ExtractKeyValueFromTaint(node.Spec.Taints)).To(HaveKeyWithValue(foreignTaint.Key, foreignTaint.Value))
+		var managers []string
+		for _, f := range node.ManagedFields {
+			managers = append(managers, f.Manager)
+		}
+		Expect(managers).To(ContainElements(consts.FieldManager, util.GenerateFieldManager(testMachineNodePoolMachineGroupName)))
+
+		Expect(k8sClient.Delete(ctx, pool, &client.DeleteOptions{})).NotTo(HaveOccurred())
+		Eventually(func() map[string]string {
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: readyTestNodeB}, node)).NotTo(HaveOccurred())
+			return node.Labels
+		}, consts.SuiteTestTimeOut).Should(Equal(map[string]string{"example.com/foreign": "true"}))
+		Eventually(func() map[string]string {
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: readyTestNodeB}, node)).NotTo(HaveOccurred())
+			nodeTaints := util.ExtractKeyValueFromTaint(node.Spec.Taints)
+			delete(nodeTaints, consts.NodeNotReadyTaint)
+			return nodeTaints
+		}, consts.SuiteTestTimeOut).Should(Equal(map[string]string{foreignTaint.Key: foreignTaint.Value}))
+		Expect(node.Annotations).To(Equal(map[string]string{"example.com/foreign": "true"}))
+	})
+
+	It("Node is deleted and recreated", func() {
+		pool := newFakeMachineNodePool(testNodes, testMachineTypeStock)
+		Expect(k8sClient.Create(ctx, pool, &client.CreateOptions{})).NotTo(HaveOccurred())
+		waitUpdateTestNode(ctx, testNodes)
+		waitUpdateTestMachineNodePoolCondition(ctx, testNodes)
+
+		// delete Node
+		Expect(k8sClient.Delete(ctx, newFakeNode(readyTestNodeA), &client.DeleteOptions{})).NotTo(HaveOccurred())
+
+		getPool := &imperatorv1alpha1.MachineNodePool{}
+		Eventually(func() imperatorv1alpha1.MachineNodeCondition {
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: testMachineNodePoolName}, getPool)).NotTo(HaveOccurred())
+			for _, c := range getPool.Status.NodePoolCondition {
+				if c.Name != readyTestNodeA {
+					continue
+				}
+				return c.NodeCondition
+			}
+			return ""
+		}, consts.SuiteTestTimeOut).Should(Equal(imperatorv1alpha1.NodeMissing))
+
+		// other Nodes keep healthy
+		for _, n := range testNodes {
+			if n.name == readyTestNodeA {
+				continue
+			}
+			for _, c := range getPool.Status.NodePoolCondition {
+				if c.Name != n.name {
+					continue
+				}
+				Expect(c.NodeCondition).To(Equal(n.status))
+			}
+		}
+
+		// recreate Node
+		Expect(k8sClient.Create(ctx, newFakeNode(readyTestNodeA))).NotTo(HaveOccurred())
+		waitUpdateTestNode(ctx, testNodes)
+		waitUpdateTestMachineNodePoolCondition(ctx, testNodes)
+	})
+
+	It("Node condition reported by node-problem-detector", func() {
+		pool := newFakeMachineNodePool(testNodes, testMachineTypeStock)
+		pool.Spec.NodeHealthPolicy = &imperatorv1alpha1.NodeHealthPolicy{
+			UnhealthyConditions: []imperatorv1alpha1.UnhealthyNodeCondition{{
+				Type:   "GPUXidError",
+				Status: corev1.ConditionTrue,
+			}},
+		}
+		Expect(k8sClient.Create(ctx, pool, &client.CreateOptions{})).NotTo(HaveOccurred())
+		waitUpdateTestNode(ctx, testNodes)
+		waitUpdateTestMachineNodePoolCondition(ctx, testNodes)
+
+		getReadyTestNodeA := &corev1.Node{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: readyTestNodeA}, getReadyTestNodeA)).NotTo(HaveOccurred())
+		getReadyTestNodeA.Status.Conditions = append(getReadyTestNodeA.Status.Conditions, corev1.NodeCondition{
+			Type:   "GPUXidError",
+			Status: corev1.ConditionTrue,
+		})
+		Expect(k8sClient.Status().Update(ctx, getReadyTestNodeA, &client.UpdateOptions{})).NotTo(HaveOccurred())
+
+		getReadyTestNodeA = &corev1.Node{}
+		Eventually(func() string {
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: readyTestNodeA}, getReadyTestNodeA)).NotTo(HaveOccurred())
+			return getReadyTestNodeA.Labels[consts.MachineStatusKey]
+		}, consts.SuiteTestTimeOut).Should(Equal(imperatorv1alpha1.NodeModeNotReady.Value()))
+
+		getPool := &imperatorv1alpha1.MachineNodePool{}
+		Eventually(func() imperatorv1alpha1.NodePoolCondition {
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: testMachineNodePoolName}, getPool)).NotTo(HaveOccurred())
+			for _, c := range getPool.Status.NodePoolCondition {
+				if c.Name != readyTestNodeA {
+					continue
+				}
+				return c
+			}
+			return imperatorv1alpha1.NodePoolCondition{}
+		}, consts.SuiteTestTimeOut).Should(Equal(imperatorv1alpha1.NodePoolCondition{
+			Name:          readyTestNodeA,
+			NodeCondition: imperatorv1alpha1.NodeUnhealthy,
+			Reason:        "condition <GPUXidError> is <True>",
+		}))
+	})
+
+	It("Drain Guest Pods from Node in maintenance mode", func() {
+		pool := newFakeMachineNodePool(testNodes, testMachineTypeStock)
+		for idx, p := range pool.Spec.NodePool {
+			if p.Name != maintenanceTestNode {
+				continue
+			}
+			pool.Spec.NodePool[idx].Drain = &imperatorv1alpha1.DrainPolicy{
+				GracePeriodSeconds: pointer.Int64(0),
+			}
+		}
+
+		// create Guest Pod running on the Node in maintenance mode
+		guestPod := newFakeGuestPod("compute-xsmall")
+		guestPod.Labels[consts.MachineGroupKey] = testMachineNodePoolMachineGroupName
+		guestPod.Spec.NodeName = maintenanceTestNode
+		Expect(k8sClient.Create(ctx, guestPod, &client.CreateOptions{})).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Create(ctx, pool, &client.CreateOptions{})).NotTo(HaveOccurred())
+		waitUpdateTestNode(ctx, testNodes)
+
+		// check Guest Pod is evicted
+		Eventually(func() bool {
+			return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(guestPod), &corev1.Pod{}))
+		}, consts.SuiteTestTimeOut).Should(BeTrue())
+
+		// check MachineNodePool status
+		getPool := &imperatorv1alpha1.MachineNodePool{}
+		Eventually(func() imperatorv1alpha1.MachineNodeCondition {
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: testMachineNodePoolName}, getPool)).NotTo(HaveOccurred())
+			for _, c := range getPool.Status.NodePoolCondition {
+				if c.Name != maintenanceTestNode {
+					continue
+				}
+				return c.NodeCondition
+			}
+			return ""
+		}, consts.SuiteTestTimeOut).Should(Equal(imperatorv1alpha1.NodeDrained))
+	})
+
 	It("Change node status", func() {
 		pool := newFakeMachineNodePool(testNodes, testMachineTypeStock)
 		Expect(k8sClient.Create(ctx, pool, &client.CreateOptions{})).NotTo(HaveOccurred())
@@ -329,7 +491,7 @@ var _ = Describe("machinenodepool controller envtest", func() {
 
 		now := metav1.Now()
 		getReadyTestNodeA.Spec.Taints = append(getReadyTestNodeA.Spec.Taints, corev1.Taint{
-			Key:       consts.CannotUseNodeTaints[1],
+			Key:       consts.DefaultUnhealthyNodeTaints[0],
 			Effect:    corev1.TaintEffectNoSchedule,
 			TimeAdded: &now,
 		})
@@ -341,7 +503,7 @@ var _ = Describe("machinenodepool controller envtest", func() {
 		Eventually(func() corev1.TaintEffect {
 			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: readyTestNodeA}, getReadyTestNodeA)).NotTo(HaveOccurred())
 			for _, t := range getReadyTestNodeA.Spec.Taints {
-				if t.Key != consts.CannotUseNodeTaints[1] {
+				if t.Key != consts.DefaultUnhealthyNodeTaints[0] {
 					continue
 				}
 				return t.Effect
@@ -373,7 +535,7 @@ var _ = Describe("machinenodepool controller envtest", func() {
 
 		now = metav1.Now()
 		getReadyTestNodeB.Spec.Taints = append(getReadyTestNodeB.Spec.Taints, corev1.Taint{
-			Key:       consts.CannotUseNodeTaints[2],
+			Key:       consts.DefaultUnhealthyNodeTaints[1],
 			Effect:    corev1.TaintEffectNoSchedule,
 			TimeAdded: &now,
 		})
@@ -383,7 +545,7 @@ var _ = Describe("machinenodepool controller envtest", func() {
 		Eventually(func() corev1.TaintEffect {
 			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: readyTestNodeB}, getReadyTestNodeB)).NotTo(HaveOccurred())
 			for _, t := range getReadyTestNodeB.Spec.Taints {
-				if t.Key != consts.CannotUseNodeTaints[2] {
+				if t.Key != consts.DefaultUnhealthyNodeTaints[1] {
 					continue
 				}
 				return t.Effect
@@ -0,0 +1,52 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+// GetMachineGroups returns machineGroups which the Node belongs to from annotations of the Node.
+// The value of the annotation is comma-separated machineGroup names.
+func GetMachineGroups(annotations map[string]string) []string {
+	value, exist := annotations[consts.MachineGroupKey]
+	if !exist || value == "" {
+		return nil
+	}
+
+	var machineGroups []string
+	for _, g := range strings.Split(value, ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			machineGroups = append(machineGroups, g)
+		}
+	}
+	sort.Strings(machineGroups)
+	return machineGroups
+}
+
+// ContainsMachineGroup checks whether the Node belongs to machineGroup.
+func ContainsMachineGroup(annotations map[string]string, machineGroup string) bool {
+	for _, g := range GetMachineGroups(annotations) {
+		if g == machineGroup {
+			return true
+		}
+	}
+	return false
+}
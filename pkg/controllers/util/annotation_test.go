@@ -0,0 +1,59 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+func TestGetMachineGroups(t *testing.T) {
+
+	testCases := []struct {
+		description string
+		annotations map[string]string
+		expected    []string
+	}{
+		{
+			description: "Node belongs to multiple machineGroups",
+			annotations: map[string]string{
+				consts.MachineGroupKey: "research,batch",
+			},
+			expected: []string{"batch", "research"},
+		},
+		{
+			description: "Node does not belong to any machineGroups",
+			annotations: map[string]string{
+				"dummy-key": "dummy-value",
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			actual := GetMachineGroups(test.annotations)
+			if diff := cmp.Diff(actual, test.expected); diff != "" {
+				t.Errorf("DIFF: \n%v\n", diff)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	imperatorv1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
+
+// DefaultUnhealthyNodeConditions is Node conditions to treat nodes as not-ready.
+var DefaultUnhealthyNodeConditions = []imperatorv1alpha1.UnhealthyNodeCondition{
+	{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+	{Type: corev1.NodeReady, Status: corev1.ConditionUnknown},
+	{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue},
+	{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+	{Type: corev1.NodePIDPressure, Status: corev1.ConditionTrue},
+}
+
+// EvaluateNodeHealth evaluates whether the Node is healthy with the built-in policy and policy.
+// If the Node is unhealthy, the reason is returned.
+func EvaluateNodeHealth(node *corev1.Node, policy *imperatorv1alpha1.NodeHealthPolicy) (bool, string) {
+	unhealthyConditions := DefaultUnhealthyNodeConditions
+	unhealthyTaints := consts.DefaultUnhealthyNodeTaints
+	if policy != nil {
+		unhealthyConditions = append(append([]imperatorv1alpha1.UnhealthyNodeCondition{}, unhealthyConditions...), policy.UnhealthyConditions...)
+		unhealthyTaints = append(append([]string{}, unhealthyTaints...), policy.UnhealthyTaints...)
+	}
+
+	for _, uc := range unhealthyConditions {
+		for _, c := range node.Status.Conditions {
+			if c.Type != uc.Type || c.Status != uc.Status {
+				continue
+			}
+			return false, fmt.Sprintf("condition <%s> is <%s>", c.Type, c.Status)
+		}
+	}
+
+	taints := ExtractKeyValueFromTaint(node.Spec.Taints)
+	for _, t := range unhealthyTaints {
+		if _, exist := taints[t]; exist {
+			return false, fmt.Sprintf("node has taint <%s>", t)
+		}
+	}
+
+	return true, ""
+}
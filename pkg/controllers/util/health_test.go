@@ -0,0 +1,123 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	imperatorv1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
+)
+
+func TestEvaluateNodeHealth(t *testing.T) {
+
+	testCases := []struct {
+		description    string
+		node           *corev1.Node
+		policy         *imperatorv1alpha1.NodeHealthPolicy
+		expectedHealth bool
+		expectedReason string
+	}{
+		{
+			description: "Healthy Node",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+						{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+					},
+				},
+			},
+			expectedHealth: true,
+			expectedReason: "",
+		},
+		{
+			description: "Ready condition is Unknown",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionUnknown},
+					},
+				},
+			},
+			expectedHealth: false,
+			expectedReason: "condition <Ready> is <Unknown>",
+		},
+		{
+			description: "Node has unschedulable taint",
+			node: &corev1.Node{
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{{
+						Key:    "node.kubernetes.io/unschedulable",
+						Effect: corev1.TaintEffectNoSchedule,
+					}},
+				},
+			},
+			expectedHealth: false,
+			expectedReason: "node has taint <node.kubernetes.io/unschedulable>",
+		},
+		{
+			description: "Custom condition reported by node-problem-detector",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+						{Type: "GPUXidError", Status: corev1.ConditionTrue},
+					},
+				},
+			},
+			policy: &imperatorv1alpha1.NodeHealthPolicy{
+				UnhealthyConditions: []imperatorv1alpha1.UnhealthyNodeCondition{{
+					Type:   "GPUXidError",
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			expectedHealth: false,
+			expectedReason: "condition <GPUXidError> is <True>",
+		},
+		{
+			description: "Custom taint",
+			node: &corev1.Node{
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{{
+						Key:    "example.com/broken",
+						Effect: corev1.TaintEffectNoSchedule,
+					}},
+				},
+			},
+			policy: &imperatorv1alpha1.NodeHealthPolicy{
+				UnhealthyTaints: []string{"example.com/broken"},
+			},
+			expectedHealth: false,
+			expectedReason: "node has taint <example.com/broken>",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			health, reason := EvaluateNodeHealth(test.node, test.policy)
+			if health != test.expectedHealth {
+				t.Errorf("GOT: %v, WANT: %v", health, test.expectedHealth)
+			}
+			if reason != test.expectedReason {
+				t.Errorf("GOT: %v, WANT: %v", reason, test.expectedReason)
+			}
+		})
+	}
+
+}
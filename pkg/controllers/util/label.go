@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"sort"
 	"strings"
 
 	imperatorv1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
@@ -45,3 +46,27 @@ func GetScheduleMachineTypeKeys(machineTypes []imperatorv1alpha1.NodePoolMachine
 	}
 	return machineTypeKeys
 }
+
+// GetMachineTypeKeys returns keys of machineType which are assigned to machineGroup from labels or taints of the Node.
+func GetMachineTypeKeys(keyValues map[string]string, machineGroup string) []string {
+	var machineTypeKeys []string
+	for key, value := range keyValues {
+		if value != machineGroup || !strings.HasPrefix(key, imperatorv1alpha1.GroupVersion.Group+"/") {
+			continue
+		}
+		if reservedKeys[key] {
+			continue
+		}
+		machineTypeKeys = append(machineTypeKeys, key)
+	}
+	sort.Strings(machineTypeKeys)
+	return machineTypeKeys
+}
+
+var reservedKeys = map[string]bool{
+	consts.MachineGroupKey:                         true,
+	consts.MachineStatusKey:                        true,
+	consts.MachineTypeKey:                          true,
+	consts.PodRoleKey:                              true,
+	consts.ImperatorResourceInjectContainerNameKey: true,
+}
@@ -92,3 +92,46 @@ func TestGetScheduleMachineTypeKeys(t *testing.T) {
 	}
 
 }
+
+func TestGetMachineTypeKeys(t *testing.T) {
+
+	testCases := []struct {
+		description  string
+		keyValues    map[string]string
+		machineGroup string
+		expected     []string
+	}{
+		{
+			description: "Multiple machineTypes are assigned to the Node",
+			keyValues: map[string]string{
+				imperatorv1alpha1.GenerateMachineTypeLabelTaintKey("compute-xlarge"): testMachineGroup,
+				imperatorv1alpha1.GenerateMachineTypeLabelTaintKey("compute-small"):  testMachineGroup,
+				consts.MachineStatusKey:  imperatorv1alpha1.NodeModeReady.Value(),
+				"kubernetes.io/hostname": testMachineGroup,
+			},
+			machineGroup: testMachineGroup,
+			expected: []string{
+				imperatorv1alpha1.GenerateMachineTypeLabelTaintKey("compute-small"),
+				imperatorv1alpha1.GenerateMachineTypeLabelTaintKey("compute-xlarge"),
+			},
+		},
+		{
+			description: "machineType assigned to other machineGroup",
+			keyValues: map[string]string{
+				imperatorv1alpha1.GenerateMachineTypeLabelTaintKey("compute-xlarge"): "other-machine-group",
+			},
+			machineGroup: testMachineGroup,
+			expected:     nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			actual := GetMachineTypeKeys(test.keyValues, test.machineGroup)
+			if diff := cmp.Diff(actual, test.expected); diff != "" {
+				t.Errorf("DIFF: \n%v\n", diff)
+			}
+		})
+	}
+
+}
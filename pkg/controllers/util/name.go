@@ -16,7 +16,11 @@ limitations under the License.
 
 package util
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/tenzen-y/imperator/pkg/consts"
+)
 
 func GenerateMachineNodePoolName(machineGroupName string) string {
 	return strings.Join([]string{
@@ -31,3 +35,11 @@ func GenerateReservationResourceName(machineGroup, machineType string) string {
 		machineType,
 	}, "-")
 }
+
+// GenerateFieldManager returns the field manager for server-side apply owning the machineGroup's keys.
+func GenerateFieldManager(machineGroup string) string {
+	return strings.Join([]string{
+		consts.FieldManager,
+		machineGroup,
+	}, "-")
+}
@@ -43,3 +43,26 @@ func TestGenerateMachineNodePoolName(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateFieldManager(t *testing.T) {
+	testCases := []struct {
+		description  string
+		machineGroup string
+		expected     string
+	}{
+		{
+			description:  "Positive test",
+			machineGroup: "test-machine-group",
+			expected:     "imperator" + "-" + "test-machine-group",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			actual := GenerateFieldManager(test.machineGroup)
+			if !strings.EqualFold(actual, test.expected) {
+				t.Errorf("WANT: \n%v\n, GOT: \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
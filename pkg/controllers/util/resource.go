@@ -19,6 +19,7 @@ package util
 import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
@@ -39,7 +40,8 @@ func GenerateSleeperContainer() corev1.Container {
 	}
 }
 
-func GenerateStatefulSet(machineType *imperatorv1alpha1.MachineType, machineGroup string, replica int32, sts *appsv1.StatefulSet) {
+func GenerateStatefulSet(machineType *imperatorv1alpha1.MachineType, machineGroup string, reservationMode imperatorv1alpha1.ReservationMode,
+	replica int32, sts *appsv1.StatefulSet) {
 	machineTypeName := machineType.Name
 	svcName := GenerateReservationResourceName(machineGroup, machineTypeName)
 	stsLabels := GenerateReservationResourceLabel(machineGroup, machineTypeName)
@@ -67,20 +69,29 @@ func GenerateStatefulSet(machineType *imperatorv1alpha1.MachineType, machineGrou
 		},
 	}
 
-	resourceList := corev1.ResourceList{
-		corev1.ResourceCPU:    machineType.Spec.CPU,
-		corev1.ResourceMemory: machineType.Spec.Memory,
-	}
-	if machineType.Spec.GPU != nil {
-		resourceList[machineType.Spec.GPU.Type] = machineType.Spec.GPU.Num
-	}
 	sts.Spec.Template.Spec.Containers = []corev1.Container{GenerateSleeperContainer()}
-	sts.Spec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
-		Requests: resourceList,
-		Limits:   resourceList,
+	sts.Spec.Template.Spec.Containers[0].Resources = imperatorv1alpha1.GenerateResourceRequirements(machineType)
+
+	// placeholder Pods are preempted by Guest Pods, so those should release resources immediately.
+	if reservationMode == imperatorv1alpha1.ReservationModePreemptible {
+		sts.Spec.Template.Spec.PriorityClassName = consts.ReservationPriorityClassName
+		sts.Spec.Template.Spec.TerminationGracePeriodSeconds = pointer.Int64(0)
+	} else {
+		sts.Spec.Template.Spec.PriorityClassName = ""
+		if grace := sts.Spec.Template.Spec.TerminationGracePeriodSeconds; grace != nil && *grace == 0 {
+			sts.Spec.Template.Spec.TerminationGracePeriodSeconds = nil
+		}
 	}
 }
 
+// GeneratePriorityClass generates PriorityClass for placeholder Pods or Guest Pods in Preemptible reservation mode.
+func GeneratePriorityClass(value int32, preemptionPolicy corev1.PreemptionPolicy, description string, pc *schedulingv1.PriorityClass) {
+	pc.Value = value
+	pc.GlobalDefault = false
+	pc.PreemptionPolicy = &preemptionPolicy
+	pc.Description = description
+}
+
 func GenerateService(machineType, machineGroup string, svc *corev1.Service) {
 	svcLabels := GenerateReservationResourceLabel(machineGroup, machineType)
 
@@ -91,3 +102,35 @@ func GenerateService(machineType, machineGroup string, svc *corev1.Service) {
 	}}
 	svc.Spec.Type = corev1.ServiceTypeClusterIP
 }
+
+// GetPodResourceRequests returns effective resource requests of the Pod that kube-scheduler considers.
+func GetPodResourceRequests(pod *corev1.Pod) corev1.ResourceList {
+	result := corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		for name, q := range c.Resources.Requests {
+			sum := result[name]
+			sum.Add(q)
+			result[name] = sum
+		}
+	}
+	for _, c := range pod.Spec.InitContainers {
+		for name, q := range c.Resources.Requests {
+			if current, exist := result[name]; exist && current.Cmp(q) >= 0 {
+				continue
+			}
+			result[name] = q.DeepCopy()
+		}
+	}
+	for name, q := range pod.Spec.Overhead {
+		sum := result[name]
+		sum.Add(q)
+		result[name] = sum
+	}
+	return result
+}
+
+// IsDaemonSetPod returns true if the Pod is controlled by a DaemonSet.
+func IsDaemonSetPod(pod *corev1.Pod) bool {
+	owner := metav1.GetControllerOf(pod)
+	return owner != nil && owner.Kind == "DaemonSet"
+}
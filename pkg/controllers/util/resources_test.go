@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
@@ -36,10 +37,11 @@ const (
 
 func TestGenerateStatefulSet(t *testing.T) {
 	testCases := []struct {
-		description  string
-		machineType  *imperatorv1alpha1.MachineType
-		machineGroup string
-		replica      int32
+		description     string
+		machineType     *imperatorv1alpha1.MachineType
+		machineGroup    string
+		reservationMode imperatorv1alpha1.ReservationMode
+		replica         int32
 	}{
 		{
 			description: "Normal machineType",
@@ -49,13 +51,22 @@ func TestGenerateStatefulSet(t *testing.T) {
 			description: "machineType with GPUs",
 			machineType: newFakeMachineType(true),
 		},
+		{
+			description:     "Preemptible reservation mode",
+			machineType:     newFakeMachineType(false),
+			reservationMode: imperatorv1alpha1.ReservationModePreemptible,
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
 			expected := newFakeStatefulSet(test.machineType)
+			if test.reservationMode == imperatorv1alpha1.ReservationModePreemptible {
+				expected.Spec.Template.Spec.PriorityClassName = consts.ReservationPriorityClassName
+				expected.Spec.Template.Spec.TerminationGracePeriodSeconds = pointer.Int64(0)
+			}
 			actual := &appsv1.StatefulSet{}
-			GenerateStatefulSet(test.machineType, testMachineGroup, 1, actual)
+			GenerateStatefulSet(test.machineType, testMachineGroup, test.reservationMode, 1, actual)
 			if diff := cmp.Diff(actual, expected, consts.CmpSliceOpts...); diff != "" {
 				t.Errorf("DIFF: \n%v\n", diff)
 			}
@@ -86,6 +97,20 @@ func TestGenerateService(t *testing.T) {
 	}
 }
 
+func TestGeneratePriorityClass(t *testing.T) {
+	preemptNever := corev1.PreemptNever
+	expected := &schedulingv1.PriorityClass{
+		Value:            consts.ReservationPriority,
+		PreemptionPolicy: &preemptNever,
+		Description:      "test",
+	}
+	actual := &schedulingv1.PriorityClass{GlobalDefault: true}
+	GeneratePriorityClass(consts.ReservationPriority, corev1.PreemptNever, "test", actual)
+	if diff := cmp.Diff(actual, expected); diff != "" {
+		t.Errorf("DIFF: \n%v\n", diff)
+	}
+}
+
 func newFakeMachineType(useGpu bool) *imperatorv1alpha1.MachineType {
 	fakeMachineType := &imperatorv1alpha1.MachineType{
 		Name: "fake-machine-type",
@@ -176,3 +201,118 @@ func newFakeService(machineTypeName string) *corev1.Service {
 		},
 	}
 }
+
+func TestGetPodResourceRequests(t *testing.T) {
+	newResourceRequirements := func(cpu, memory string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+		}
+	}
+
+	testCases := []struct {
+		description string
+		pod         *corev1.Pod
+		expected    corev1.ResourceList
+	}{
+		{
+			description: "Sum of containers",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Resources: newResourceRequirements("1", "1Gi")},
+						{Resources: newResourceRequirements("500m", "512Mi")},
+					},
+				},
+			},
+			expected: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1500m"),
+				corev1.ResourceMemory: resource.MustParse("1536Mi"),
+			},
+		},
+		{
+			description: "InitContainer requests more resources than containers",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{Resources: newResourceRequirements("2", "512Mi")},
+					},
+					Containers: []corev1.Container{
+						{Resources: newResourceRequirements("1", "1Gi")},
+					},
+				},
+			},
+			expected: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			actual := GetPodResourceRequests(test.pod)
+			for name, q := range test.expected {
+				a := actual[name]
+				if a.Cmp(q) != 0 {
+					t.Errorf("%s; GOT: \n%v\n, WANT: \n%v\n", name, a.String(), q.String())
+				}
+			}
+			if len(actual) != len(test.expected) {
+				t.Errorf("GOT: \n%v\n, WANT: \n%v\n", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestIsDaemonSetPod(t *testing.T) {
+	newOwnerReference := func(kind string, controller bool) metav1.OwnerReference {
+		return metav1.OwnerReference{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       kind,
+			Name:       "test",
+			Controller: pointer.Bool(controller),
+		}
+	}
+
+	testCases := []struct {
+		description     string
+		ownerReferences []metav1.OwnerReference
+		expected        bool
+	}{
+		{
+			description:     "Controlled by DaemonSet",
+			ownerReferences: []metav1.OwnerReference{newOwnerReference("DaemonSet", true)},
+			expected:        true,
+		},
+		{
+			description:     "Controlled by ReplicaSet",
+			ownerReferences: []metav1.OwnerReference{newOwnerReference("ReplicaSet", true)},
+			expected:        false,
+		},
+		{
+			description:     "DaemonSet is not controller",
+			ownerReferences: []metav1.OwnerReference{newOwnerReference("DaemonSet", false)},
+			expected:        false,
+		},
+		{
+			description: "No owner",
+			expected:    false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: test.ownerReferences,
+				},
+			}
+			if actual := IsDaemonSetPod(pod); actual != test.expected {
+				t.Errorf("GOT: %v, WANT: %v", actual, test.expected)
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package util
 
 import (
+	"sort"
+
 	corev1 "k8s.io/api/core/v1"
 
 	imperatorv1alpha1 "github.com/tenzen-y/imperator/pkg/api/v1alpha1"
@@ -45,3 +47,68 @@ func GetPodConditionTypeMap(podConditions []corev1.PodCondition) map[corev1.PodC
 	}
 	return result
 }
+
+// GenerateNamespaceUsage converts the number of used machineType units per namespace to NamespaceUsageCondition sorted by namespace.
+func GenerateNamespaceUsage(namespaceUsed map[string]int32) []imperatorv1alpha1.NamespaceUsageCondition {
+	if len(namespaceUsed) == 0 {
+		return nil
+	}
+	result := make([]imperatorv1alpha1.NamespaceUsageCondition, 0, len(namespaceUsed))
+	for ns, used := range namespaceUsed {
+		result = append(result, imperatorv1alpha1.NamespaceUsageCondition{
+			Namespace: ns,
+			Used:      used,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace < result[j].Namespace
+	})
+	return result
+}
+
+// RemoveStaleAvailableMachines removes availableMachines for machineTypes which are not in activeMachineTypes.
+// It returns the remaining availableMachines and names of removed machineTypes.
+func RemoveStaleAvailableMachines(availableMachines []imperatorv1alpha1.AvailableMachineCondition,
+	activeMachineTypes map[string]bool) ([]imperatorv1alpha1.AvailableMachineCondition, []string) {
+
+	var result []imperatorv1alpha1.AvailableMachineCondition
+	var removed []string
+	for _, am := range availableMachines {
+		if !activeMachineTypes[am.Name] {
+			removed = append(removed, am.Name)
+			continue
+		}
+		result = append(result, am)
+	}
+	return result, removed
+}
+
+// GenerateNodeUsage converts the number of reservation Pods and Guest Pods per Node to NodeUsageCondition sorted by Node name.
+// nodeConditions is conditions of Nodes in MachineNodePool,
+// and reserved and used are the number of Pods per Node name and machineType name.
+func GenerateNodeUsage(nodePool []imperatorv1alpha1.NodePool, nodeConditions map[string]imperatorv1alpha1.MachineNodeCondition,
+	reserved, used map[string]map[string]int32) []imperatorv1alpha1.NodeUsageCondition {
+
+	if len(nodePool) == 0 {
+		return nil
+	}
+	var result []imperatorv1alpha1.NodeUsageCondition
+	for _, np := range nodePool {
+		condition := imperatorv1alpha1.NodeUsageCondition{
+			Name:      np.Name,
+			Condition: nodeConditions[np.Name],
+		}
+		for _, npmt := range np.MachineType {
+			condition.MachineTypes = append(condition.MachineTypes, imperatorv1alpha1.NodeMachineTypeUsage{
+				Name:     npmt.Name,
+				Reserved: reserved[np.Name][npmt.Name],
+				Used:     used[np.Name][npmt.Name],
+			})
+		}
+		result = append(result, condition)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -162,3 +162,163 @@ func newFakeMapPodConditions(now metav1.Time) map[corev1.PodConditionType]corev1
 		},
 	}
 }
+
+func TestGenerateNamespaceUsage(t *testing.T) {
+
+	testCases := []struct {
+		description   string
+		namespaceUsed map[string]int32
+		expected      []imperatorv1alpha1.NamespaceUsageCondition
+	}{
+		{
+			description: "Multiple namespaces",
+			namespaceUsed: map[string]int32{
+				"team-b": 2,
+				"team-a": 1,
+			},
+			expected: []imperatorv1alpha1.NamespaceUsageCondition{
+				{Namespace: "team-a", Used: 1},
+				{Namespace: "team-b", Used: 2},
+			},
+		},
+		{
+			description:   "There are no guest Pods",
+			namespaceUsed: map[string]int32{},
+			expected:      nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			actual := GenerateNamespaceUsage(test.namespaceUsed)
+			if diff := cmp.Diff(actual, test.expected); diff != "" {
+				t.Errorf("DIFF: \n%v\n", diff)
+			}
+		})
+	}
+}
+
+func TestRemoveStaleAvailableMachines(t *testing.T) {
+
+	testCases := []struct {
+		description        string
+		availableMachines  []imperatorv1alpha1.AvailableMachineCondition
+		activeMachineTypes map[string]bool
+		expected           []imperatorv1alpha1.AvailableMachineCondition
+		expectedRemoved    []string
+	}{
+		{
+			description: "machineType is removed from machineTypes or every nodePool",
+			availableMachines: []imperatorv1alpha1.AvailableMachineCondition{
+				{Name: "compute-small"},
+				{Name: "compute-large"},
+			},
+			activeMachineTypes: map[string]bool{
+				"compute-small": true,
+			},
+			expected: []imperatorv1alpha1.AvailableMachineCondition{
+				{Name: "compute-small"},
+			},
+			expectedRemoved: []string{"compute-large"},
+		},
+		{
+			description: "There are no stale availableMachines",
+			availableMachines: []imperatorv1alpha1.AvailableMachineCondition{
+				{Name: "compute-small"},
+			},
+			activeMachineTypes: map[string]bool{
+				"compute-small": true,
+				"compute-large": true,
+			},
+			expected: []imperatorv1alpha1.AvailableMachineCondition{
+				{Name: "compute-small"},
+			},
+			expectedRemoved: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			actual, actualRemoved := RemoveStaleAvailableMachines(test.availableMachines, test.activeMachineTypes)
+			if diff := cmp.Diff(actual, test.expected); diff != "" {
+				t.Errorf("DIFF: \n%v\n", diff)
+			}
+			if diff := cmp.Diff(actualRemoved, test.expectedRemoved); diff != "" {
+				t.Errorf("DIFF: \n%v\n", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateNodeUsage(t *testing.T) {
+
+	testCases := []struct {
+		description    string
+		nodePool       []imperatorv1alpha1.NodePool
+		nodeConditions map[string]imperatorv1alpha1.MachineNodeCondition
+		reserved       map[string]map[string]int32
+		used           map[string]map[string]int32
+		expected       []imperatorv1alpha1.NodeUsageCondition
+	}{
+		{
+			description: "Multiple nodes",
+			nodePool: []imperatorv1alpha1.NodePool{
+				{
+					Name: "node-b",
+					Mode: imperatorv1alpha1.NodeModeMaintenance,
+					MachineType: []imperatorv1alpha1.NodePoolMachineType{
+						{Name: "compute-small"},
+					},
+				},
+				{
+					Name: "node-a",
+					Mode: imperatorv1alpha1.NodeModeReady,
+					MachineType: []imperatorv1alpha1.NodePoolMachineType{
+						{Name: "compute-small"},
+						{Name: "compute-large"},
+					},
+				},
+			},
+			nodeConditions: map[string]imperatorv1alpha1.MachineNodeCondition{
+				"node-a": imperatorv1alpha1.NodeHealthy,
+				"node-b": imperatorv1alpha1.NodeMaintenance,
+			},
+			reserved: map[string]map[string]int32{
+				"node-a": {"compute-small": 1},
+			},
+			used: map[string]map[string]int32{
+				"node-a": {"compute-small": 1, "compute-large": 2},
+			},
+			expected: []imperatorv1alpha1.NodeUsageCondition{
+				{
+					Name:      "node-a",
+					Condition: imperatorv1alpha1.NodeHealthy,
+					MachineTypes: []imperatorv1alpha1.NodeMachineTypeUsage{
+						{Name: "compute-small", Reserved: 1, Used: 1},
+						{Name: "compute-large", Reserved: 0, Used: 2},
+					},
+				},
+				{
+					Name:      "node-b",
+					Condition: imperatorv1alpha1.NodeMaintenance,
+					MachineTypes: []imperatorv1alpha1.NodeMachineTypeUsage{
+						{Name: "compute-small", Reserved: 0, Used: 0},
+					},
+				},
+			},
+		},
+		{
+			description: "There are no nodes",
+			expected:    nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			actual := GenerateNodeUsage(test.nodePool, test.nodeConditions, test.reserved, test.used)
+			if diff := cmp.Diff(actual, test.expected); diff != "" {
+				t.Errorf("DIFF: \n%v\n", diff)
+			}
+		})
+	}
+}
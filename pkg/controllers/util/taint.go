@@ -18,7 +18,6 @@ package util
 
 import (
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/utils/pointer"
 )
 
 func ExtractKeyValueFromTaint(taints []corev1.Taint) map[string]string {
@@ -29,12 +28,43 @@ func ExtractKeyValueFromTaint(taints []corev1.Taint) map[string]string {
 	return result
 }
 
-func GetTaintKeyIndex(taints []corev1.Taint, key string) *int {
-	for idx, t := range taints {
-		if t.Key != key {
+// MergeTaints removes taints with removeKeys and sets desired taints, keeping other taints as it is.
+// TimeAdded of the taint which does not change is kept.
+func MergeTaints(taints []corev1.Taint, removeKeys []string, desired []corev1.Taint) []corev1.Taint {
+	remove := make(map[string]bool, len(removeKeys))
+	for _, key := range removeKeys {
+		remove[key] = true
+	}
+	desiredIdx := make(map[string]int, len(desired))
+	for idx, d := range desired {
+		desiredIdx[d.Key] = idx
+	}
+
+	var result []corev1.Taint
+	merged := make(map[string]bool, len(desired))
+	for _, t := range taints {
+		if idx, exist := desiredIdx[t.Key]; exist {
+			if merged[t.Key] {
+				continue
+			}
+			d := desired[idx]
+			if d.Value == t.Value && d.Effect == t.Effect {
+				d.TimeAdded = t.TimeAdded
+			}
+			result = append(result, d)
+			merged[t.Key] = true
+			continue
+		}
+		if remove[t.Key] {
 			continue
 		}
-		return pointer.Int(idx)
+		result = append(result, t)
 	}
-	return nil
+	for _, d := range desired {
+		if merged[d.Key] {
+			continue
+		}
+		result = append(result, d)
+	}
+	return result
 }
@@ -22,9 +22,9 @@ import (
 	"github.com/tenzen-y/imperator/pkg/consts"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/utils/pointer"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestExtractKeyValueFromTaint(t *testing.T) {
@@ -53,38 +53,95 @@ func TestExtractKeyValueFromTaint(t *testing.T) {
 	}
 }
 
-func TestGetTaintKeyIndex(t *testing.T) {
+func TestMergeTaints(t *testing.T) {
+	machineTypeKey := strings.Join([]string{imperatorv1alpha1.GroupVersion.Group, "compute-xlarge"}, "/")
+	past := metav1.NewTime(metav1.Now().Add(-time.Hour))
+	foreignTaint := corev1.Taint{
+		Key:    "example.com/foreign",
+		Value:  "true",
+		Effect: corev1.TaintEffectNoExecute,
+	}
+
 	testCases := []struct {
 		description string
 		taints      []corev1.Taint
-		key         string
-		expected    *int
+		removeKeys  []string
+		desired     []corev1.Taint
+		expected    []corev1.Taint
 	}{
 		{
-			description: "Positive test",
-			taints:      newFakeTaints(),
-			key:         consts.MachineStatusKey,
-			expected:    pointer.Int(1),
+			description: "Add taints and keep foreign taints",
+			taints:      []corev1.Taint{foreignTaint},
+			desired: []corev1.Taint{{
+				Key:       consts.MachineStatusKey,
+				Value:     imperatorv1alpha1.NodeModeReady.Value(),
+				Effect:    corev1.TaintEffectNoSchedule,
+				TimeAdded: &past,
+			}},
+			expected: []corev1.Taint{foreignTaint, {
+				Key:       consts.MachineStatusKey,
+				Value:     imperatorv1alpha1.NodeModeReady.Value(),
+				Effect:    corev1.TaintEffectNoSchedule,
+				TimeAdded: &past,
+			}},
 		},
 		{
-			description: "There is not specified key in taints",
-			taints:      newFakeTaints(),
-			key:         "not-exist",
-			expected:    nil,
+			description: "Remove taints and keep foreign taints",
+			taints: []corev1.Taint{{
+				Key:    machineTypeKey,
+				Value:  "test-machine-group",
+				Effect: corev1.TaintEffectNoSchedule,
+			}, foreignTaint},
+			removeKeys: []string{machineTypeKey, consts.MachineStatusKey},
+			expected:   []corev1.Taint{foreignTaint},
+		},
+		{
+			description: "Keep TimeAdded of unchanged taint",
+			taints: []corev1.Taint{{
+				Key:       consts.MachineStatusKey,
+				Value:     imperatorv1alpha1.NodeModeReady.Value(),
+				Effect:    corev1.TaintEffectNoSchedule,
+				TimeAdded: &past,
+			}},
+			desired: []corev1.Taint{{
+				Key:    consts.MachineStatusKey,
+				Value:  imperatorv1alpha1.NodeModeReady.Value(),
+				Effect: corev1.TaintEffectNoSchedule,
+			}},
+			expected: []corev1.Taint{{
+				Key:       consts.MachineStatusKey,
+				Value:     imperatorv1alpha1.NodeModeReady.Value(),
+				Effect:    corev1.TaintEffectNoSchedule,
+				TimeAdded: &past,
+			}},
+		},
+		{
+			description: "Replace changed taint",
+			taints: []corev1.Taint{{
+				Key:       consts.MachineStatusKey,
+				Value:     imperatorv1alpha1.NodeModeReady.Value(),
+				Effect:    corev1.TaintEffectNoSchedule,
+				TimeAdded: &past,
+			}},
+			removeKeys: []string{consts.MachineStatusKey},
+			desired: []corev1.Taint{{
+				Key:    consts.MachineStatusKey,
+				Value:  imperatorv1alpha1.NodeModeMaintenance.Value(),
+				Effect: corev1.TaintEffectNoSchedule,
+			}},
+			expected: []corev1.Taint{{
+				Key:    consts.MachineStatusKey,
+				Value:  imperatorv1alpha1.NodeModeMaintenance.Value(),
+				Effect: corev1.TaintEffectNoSchedule,
+			}},
 		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
-			actual := GetTaintKeyIndex(test.taints, test.key)
-			if test.expected == nil {
-				if actual != nil {
-					t.Errorf("GOT: \n%v\n, WANT: \n%v\n", actual, nil)
-				}
-			} else {
-				if *actual != *test.expected {
-					t.Errorf("GOT: \n%v\n, WANT: \n%v\n", *actual, *test.expected)
-				}
+			actual := MergeTaints(test.taints, test.removeKeys, test.desired)
+			if diff := cmp.Diff(actual, test.expected); diff != "" {
+				t.Errorf("DIFF: \n%v\n", diff)
 			}
 		})
 	}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "imperator"
+
+	MachineGroupLabel = "machine_group"
+	MachineTypeLabel  = "machine_type"
+	ConditionLabel    = "condition"
+	ReasonLabel       = "reason"
+
+	// UnknownLabelValue is recorded for machineGroups and machineTypes which do not exist,
+	// since those are given by labels of Pods and unbounded.
+	UnknownLabelValue = "unknown"
+
+	// Reasons why the pod resource injector denied Pods.
+	DenialReasonPodUpdateForbidden   = "PodUpdateForbidden"
+	DenialReasonMachineGroupNotFound = "MachineGroupNotFound"
+	DenialReasonMachineTypeNotFound  = "MachineTypeNotFound"
+	DenialReasonNoReservedMachine    = "NoReservedMachine"
+	DenialReasonMachineQuotaExceeded = "MachineQuotaExceeded"
+	DenialReasonInvalidResourceSplit = "InvalidResourceSplit"
+	DenialReasonNamespaceNotEnabled  = "NamespaceNotEnabled"
+	DenialReasonMachineAccessDenied  = "MachineAccessDenied"
+)
+
+var (
+	MachineTypeMaximum = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "machine_type_maximum",
+		Help:      "The available maximum quantity of machineType set in Machine.",
+	}, []string{MachineGroupLabel, MachineTypeLabel})
+
+	MachineTypeReserved = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "machine_type_reserved",
+		Help:      "The number of running or creating Reservation Pods for machineType.",
+	}, []string{MachineGroupLabel, MachineTypeLabel})
+
+	MachineTypeUsed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "machine_type_used",
+		Help:      "The number of running or creating Guest Pods for machineType.",
+	}, []string{MachineGroupLabel, MachineTypeLabel})
+
+	MachineTypeWaiting = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "machine_type_waiting",
+		Help:      "The number of Guest Pods for machineType that have not yet been scheduled to any Nodes.",
+	}, []string{MachineGroupLabel, MachineTypeLabel})
+
+	NodePoolNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "node_pool_nodes",
+		Help:      "The number of Nodes in MachineNodePool for each condition.",
+	}, []string{MachineGroupLabel, ConditionLabel})
+
+	PodInjections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pod_injections_total",
+		Help:      "The number of Guest Pods the pod resource injector injected resources to.",
+	}, []string{MachineGroupLabel, MachineTypeLabel})
+
+	PodInjectionDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pod_injection_denials_total",
+		Help:      "The number of Guest Pods the pod resource injector denied.",
+	}, []string{MachineGroupLabel, ReasonLabel})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		MachineTypeMaximum,
+		MachineTypeReserved,
+		MachineTypeUsed,
+		MachineTypeWaiting,
+		NodePoolNodes,
+		PodInjections,
+		PodInjectionDenials,
+	)
+}
+
+// SetMachineTypeUsage records UsageCondition of machineType.
+func SetMachineTypeUsage(machineGroup, machineType string, maximum, reserved, used, waiting int32) {
+	MachineTypeMaximum.WithLabelValues(machineGroup, machineType).Set(float64(maximum))
+	MachineTypeReserved.WithLabelValues(machineGroup, machineType).Set(float64(reserved))
+	MachineTypeUsed.WithLabelValues(machineGroup, machineType).Set(float64(used))
+	MachineTypeWaiting.WithLabelValues(machineGroup, machineType).Set(float64(waiting))
+}
+
+// DeleteMachineTypeUsage removes UsageCondition of machineType removed from Machine.
+func DeleteMachineTypeUsage(machineGroup, machineType string) {
+	MachineTypeMaximum.DeleteLabelValues(machineGroup, machineType)
+	MachineTypeReserved.DeleteLabelValues(machineGroup, machineType)
+	MachineTypeUsed.DeleteLabelValues(machineGroup, machineType)
+	MachineTypeWaiting.DeleteLabelValues(machineGroup, machineType)
+}
+
+// SetNodePoolNodes records the number of Nodes for each condition.
+// conditions which are not in nodeNum are recorded as 0.
+func SetNodePoolNodes(machineGroup string, conditions []string, nodeNum map[string]int) {
+	for _, c := range conditions {
+		NodePoolNodes.WithLabelValues(machineGroup, c).Set(float64(nodeNum[c]))
+	}
+}
+
+// DeleteNodePoolNodes removes the number of Nodes for MachineNodePool.
+func DeleteNodePoolNodes(machineGroup string, conditions []string) {
+	for _, c := range conditions {
+		NodePoolNodes.DeleteLabelValues(machineGroup, c)
+	}
+}
+
+func RecordPodInjection(machineGroup, machineType string) {
+	PodInjections.WithLabelValues(machineGroup, machineType).Inc()
+}
+
+func RecordPodInjectionDenial(machineGroup, reason string) {
+	PodInjectionDenials.WithLabelValues(machineGroup, reason).Inc()
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 Yuki Iwai (@tenzen-y)
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMachineTypeUsage(t *testing.T) {
+	SetMachineTypeUsage("test-group", "test-machine1", 4, 3, 2, 1)
+	SetMachineTypeUsage("test-group", "test-machine2", 1, 1, 0, 0)
+
+	tests := []struct {
+		description string
+		actual      float64
+		expected    float64
+	}{
+		{description: "maximum", actual: testutil.ToFloat64(MachineTypeMaximum.WithLabelValues("test-group", "test-machine1")), expected: 4},
+		{description: "reserved", actual: testutil.ToFloat64(MachineTypeReserved.WithLabelValues("test-group", "test-machine1")), expected: 3},
+		{description: "used", actual: testutil.ToFloat64(MachineTypeUsed.WithLabelValues("test-group", "test-machine1")), expected: 2},
+		{description: "waiting", actual: testutil.ToFloat64(MachineTypeWaiting.WithLabelValues("test-group", "test-machine1")), expected: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			if test.actual != test.expected {
+				t.Fatalf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+
+	DeleteMachineTypeUsage("test-group", "test-machine1")
+	if count := testutil.CollectAndCount(MachineTypeMaximum); count != 1 {
+		t.Fatalf("expected: 1, actual: %d; series for removed machineType remain", count)
+	}
+}
+
+func TestNodePoolNodes(t *testing.T) {
+	conditions := []string{"Healthy", "Unhealthy", "Maintenance"}
+	SetNodePoolNodes("test-group", conditions, map[string]int{"Healthy": 2, "Maintenance": 1})
+
+	expected := map[string]float64{"Healthy": 2, "Unhealthy": 0, "Maintenance": 1}
+	for c, num := range expected {
+		if actual := testutil.ToFloat64(NodePoolNodes.WithLabelValues("test-group", c)); actual != num {
+			t.Fatalf("condition: %s, expected: %v, actual: %v", c, num, actual)
+		}
+	}
+
+	DeleteNodePoolNodes("test-group", conditions)
+	if count := testutil.CollectAndCount(NodePoolNodes); count != 0 {
+		t.Fatalf("expected: 0, actual: %d; series for removed MachineNodePool remain", count)
+	}
+}